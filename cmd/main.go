@@ -13,6 +13,7 @@ import (
 	"todo-api/internal/repository/memory"
 	"todo-api/internal/repository/postgres"
 	"todo-api/internal/service"
+	"todo-api/internal/storage"
 
 	"github.com/gin-gonic/gin"
 	_ "github.com/lib/pq"
@@ -81,23 +82,61 @@ func main() {
 	var repo *repository.Repository
 	if db != nil && !useInMemory {
 		repo = &repository.Repository{
-			Task: postgres.NewTaskRepository(db),
-			User: postgres.NewUserRepository(db),
+			Task:       postgres.NewTaskRepository(db),
+			User:       postgres.NewUserRepository(db),
+			Tag:        postgres.NewTagRepository(db),
+			Project:    postgres.NewProjectRepository(db),
+			Comment:    postgres.NewCommentRepository(db),
+			Attachment: postgres.NewAttachmentRepository(db),
+			Workspace:  postgres.NewWorkspaceRepository(db),
+			Transition: postgres.NewTransitionRepository(db),
+			Workflow:   postgres.NewWorkflowRepository(db),
+			History:    postgres.NewHistoryRepository(db),
+			TimeEntry:  postgres.NewTimeEntryRepository(db),
+			View:       postgres.NewViewRepository(db),
+			Template:   postgres.NewTemplateRepository(db),
+			Transactor: postgres.NewTransactor(db),
 		}
 	} else {
-		repo = &repository.Repository{
-			Task: memory.NewTaskRepository(),
-			User: memory.NewUserRepository(),
-		}
+		repo = memory.NewRepository()
+	}
+
+	blobStore, err := storage.NewLocalStore(cfg.StorageRoot)
+	if err != nil {
+		log.Fatalf("Failed to initialize attachment storage: %v", err)
 	}
 
-	authService := service.NewAuthService(repo.User, cfg.JWTSecret)
-	taskService := service.NewTaskService(repo.Task)
-	userService := service.NewUserService(repo.User)
+	authService := service.NewAuthService(repo, cfg.JWTSecret)
+	taskService := service.NewTaskService(repo, blobStore, service.TaskOptions{
+		RequireSubtasksCompleted: cfg.RequireSubtasksCompleted,
+	})
+	if cfg.TrashRetention > 0 && cfg.TrashPurgeInterval > 0 {
+		go purgeTrash(taskService, cfg.TrashRetention, cfg.TrashPurgeInterval)
+	}
+
+	userService := service.NewUserService(repo)
+	tagService := service.NewTagService(repo.Tag)
+	projectService := service.NewProjectService(repo, taskService)
+	commentService := service.NewCommentService(repo)
+	attachmentService := service.NewAttachmentService(repo, blobStore, cfg.MaxUploadSize)
+	workspaceService := service.NewWorkspaceService(repo)
+	timeService := service.NewTimeService(repo)
+	reportService := service.NewReportService(repo)
+	viewService := service.NewViewService(repo, taskService)
+	templateService := service.NewTemplateService(repo, taskService)
 
 	authHandler := handlers.NewAuthHandler(authService)
 	taskHandler := handlers.NewTaskHandler(taskService)
 	userHandler := handlers.NewUserHandler(userService)
+	tagHandler := handlers.NewTagHandler(tagService)
+	projectHandler := handlers.NewProjectHandler(projectService, taskService)
+	commentHandler := handlers.NewCommentHandler(commentService)
+	attachmentHandler := handlers.NewAttachmentHandler(attachmentService)
+	workspaceHandler := handlers.NewWorkspaceHandler(workspaceService, authService)
+	timeHandler := handlers.NewTimeHandler(timeService)
+	reportHandler := handlers.NewReportHandler(reportService)
+	viewHandler := handlers.NewViewHandler(viewService)
+	templateHandler := handlers.NewTemplateHandler(templateService)
 
 	r := gin.Default()
 
@@ -110,11 +149,96 @@ func main() {
 	protectedRoute := r.Group("/api")
 	protectedRoute.Use(middleware.AuthMiddleware(cfg.JWTSecret))
 	{
+		protectedRoute.GET("/board", taskHandler.GetBoard)
 		protectedRoute.GET("/tasks", taskHandler.GetTasks)
+		protectedRoute.GET("/tasks/search", taskHandler.SearchTasks)
 		protectedRoute.GET("/tasks/:id", taskHandler.GetTask)
 		protectedRoute.POST("/tasks", taskHandler.CreateTask)
+		protectedRoute.POST("/tasks/bulk", taskHandler.BulkUpdateTasks)
 		protectedRoute.PUT("/tasks/:id", taskHandler.UpdateTask)
 		protectedRoute.DELETE("/tasks/:id", taskHandler.DeleteTask)
+		protectedRoute.POST("/tasks/:id/restore", taskHandler.RestoreTask)
+		protectedRoute.POST("/tasks/:id/archive", taskHandler.ArchiveTask)
+		protectedRoute.POST("/tasks/:id/unarchive", taskHandler.UnarchiveTask)
+		protectedRoute.GET("/trash", taskHandler.GetTrash)
+		protectedRoute.DELETE("/trash/:id", taskHandler.PurgeTask)
+		protectedRoute.GET("/tasks/:id/history", taskHandler.GetHistory)
+		protectedRoute.GET("/tasks/:id/transitions", taskHandler.GetTransitions)
+		protectedRoute.POST("/tasks/:id/transitions", taskHandler.TransitionTask)
+		protectedRoute.POST("/tasks/:id/move", taskHandler.MoveTask)
+		protectedRoute.POST("/tasks/:id/assign", taskHandler.AssignTask)
+		protectedRoute.DELETE("/tasks/:id/assign", taskHandler.UnassignTask)
+		protectedRoute.POST("/tasks/:id/subtasks", taskHandler.CreateSubtask)
+		protectedRoute.GET("/tasks/:id/tree", taskHandler.GetTaskTree)
+		protectedRoute.GET("/tasks/:id/occurrences", taskHandler.GetOccurrences)
+		protectedRoute.POST("/tasks/:id/dependencies", taskHandler.AddDependency)
+		protectedRoute.DELETE("/tasks/:id/dependencies/:depends_on_id", taskHandler.RemoveDependency)
+		protectedRoute.POST("/tasks/:id/tags/:tag_id", taskHandler.AttachTag)
+		protectedRoute.DELETE("/tasks/:id/tags/:tag_id", taskHandler.DetachTag)
+
+		protectedRoute.GET("/tasks/:id/comments", commentHandler.GetComments)
+		protectedRoute.POST("/tasks/:id/comments", commentHandler.CreateComment)
+		protectedRoute.PUT("/tasks/:id/comments/:comment_id", commentHandler.UpdateComment)
+		protectedRoute.DELETE("/tasks/:id/comments/:comment_id", commentHandler.DeleteComment)
+
+		protectedRoute.GET("/tasks/:id/attachments", attachmentHandler.GetAttachments)
+		protectedRoute.POST("/tasks/:id/attachments", attachmentHandler.UploadAttachment)
+		protectedRoute.GET("/tasks/:id/attachments/:attachment_id", attachmentHandler.DownloadAttachment)
+		protectedRoute.DELETE("/tasks/:id/attachments/:attachment_id", attachmentHandler.DeleteAttachment)
+
+		protectedRoute.GET("/tasks/:id/time-entries", timeHandler.GetTimeEntries)
+		protectedRoute.POST("/tasks/:id/time-entries", timeHandler.CreateTimeEntry)
+		protectedRoute.PUT("/tasks/:id/time-entries/:entry_id", timeHandler.UpdateTimeEntry)
+		protectedRoute.DELETE("/tasks/:id/time-entries/:entry_id", timeHandler.DeleteTimeEntry)
+		protectedRoute.POST("/tasks/:id/timer/start", timeHandler.StartTimer)
+		protectedRoute.GET("/timer", timeHandler.GetTimer)
+		protectedRoute.POST("/timer/stop", timeHandler.StopTimer)
+		protectedRoute.GET("/time/totals", timeHandler.GetTotals)
+		protectedRoute.GET("/reports/estimates", reportHandler.GetEstimateReport)
+
+		protectedRoute.GET("/views", viewHandler.GetViews)
+		protectedRoute.GET("/views/:id", viewHandler.GetView)
+		protectedRoute.GET("/views/:id/tasks", viewHandler.GetViewTasks)
+		protectedRoute.POST("/views", viewHandler.CreateView)
+		protectedRoute.PUT("/views/:id", viewHandler.UpdateView)
+		protectedRoute.DELETE("/views/:id", viewHandler.DeleteView)
+
+		protectedRoute.GET("/templates", templateHandler.GetTemplates)
+		protectedRoute.GET("/templates/:id", templateHandler.GetTemplate)
+		protectedRoute.POST("/templates", templateHandler.CreateTemplate)
+		protectedRoute.PUT("/templates/:id", templateHandler.UpdateTemplate)
+		protectedRoute.DELETE("/templates/:id", templateHandler.DeleteTemplate)
+		protectedRoute.POST("/templates/:id/instantiate", templateHandler.InstantiateTemplate)
+
+		protectedRoute.GET("/tags", tagHandler.GetTags)
+		protectedRoute.GET("/tags/:id", tagHandler.GetTag)
+		protectedRoute.POST("/tags", tagHandler.CreateTag)
+		protectedRoute.PUT("/tags/:id", tagHandler.UpdateTag)
+		protectedRoute.DELETE("/tags/:id", tagHandler.DeleteTag)
+
+		protectedRoute.GET("/projects", projectHandler.GetProjects)
+		protectedRoute.GET("/projects/shared", projectHandler.GetSharedProjects)
+		protectedRoute.GET("/projects/:id", projectHandler.GetProject)
+		protectedRoute.GET("/projects/:id/tasks", projectHandler.GetProjectTasks)
+		protectedRoute.POST("/projects/:id/tasks/archive", projectHandler.ArchiveProjectTasks)
+		protectedRoute.POST("/projects", projectHandler.CreateProject)
+		protectedRoute.PUT("/projects/:id", projectHandler.UpdateProject)
+		protectedRoute.DELETE("/projects/:id", projectHandler.DeleteProject)
+		protectedRoute.GET("/projects/:id/workflow", projectHandler.GetWorkflow)
+		protectedRoute.PUT("/projects/:id/workflow", projectHandler.UpdateWorkflow)
+		protectedRoute.GET("/projects/:id/members", projectHandler.GetMembers)
+		protectedRoute.POST("/projects/:id/members", projectHandler.AddMember)
+		protectedRoute.PUT("/projects/:id/members/:user_id", projectHandler.UpdateMember)
+		protectedRoute.DELETE("/projects/:id/members/:user_id", projectHandler.RemoveMember)
+
+		protectedRoute.GET("/workspaces", workspaceHandler.GetWorkspaces)
+		protectedRoute.POST("/workspaces", workspaceHandler.CreateWorkspace)
+		protectedRoute.POST("/workspaces/:id/switch", workspaceHandler.SwitchWorkspace)
+		protectedRoute.GET("/workspaces/:id/members", workspaceHandler.GetMembers)
+		protectedRoute.POST("/workspaces/:id/invitations", workspaceHandler.InviteMember)
+		protectedRoute.GET("/invitations", workspaceHandler.GetInvitations)
+		protectedRoute.POST("/invitations/:id/accept", workspaceHandler.AcceptInvitation)
+		protectedRoute.DELETE("/invitations/:id", workspaceHandler.DeclineInvitation)
 
 		protectedRoute.GET("/users", userHandler.GetUsers)
 		protectedRoute.GET("/users/:id", userHandler.GetUser)
@@ -133,3 +257,20 @@ func main() {
 		log.Fatal(err)
 	}
 }
+
+// purgeTrash periodically removes tasks that have outlived the trash retention.
+func purgeTrash(taskService *service.TaskService, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := taskService.PurgeExpired(retention)
+		if err != nil {
+			log.Printf("Failed to purge trash: %v", err)
+		} else if purged > 0 {
+			log.Printf("Purged %d tasks from the trash", purged)
+		}
+
+		<-ticker.C
+	}
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 )
 
 type Config struct {
@@ -14,6 +16,16 @@ type Config struct {
 	DBSSLMode  string
 	Port       string
 	JWTSecret  string
+
+	RequireSubtasksCompleted bool
+
+	StorageRoot   string
+	MaxUploadSize int64
+
+	// TrashRetention is how long deleted tasks stay in the trash before the
+	// background purge removes them; zero keeps them until purged by hand.
+	TrashRetention     time.Duration
+	TrashPurgeInterval time.Duration
 }
 
 func LoadConfig() *Config {
@@ -26,6 +38,14 @@ func LoadConfig() *Config {
 		DBSSLMode:  getEnv("DB_SSL_MODE", "disable"),
 		Port:       getEnv("PORT", "8080"),
 		JWTSecret:  getEnv("JWT_SECRET", "secret_api_key"),
+
+		RequireSubtasksCompleted: getEnvBool("REQUIRE_SUBTASKS_COMPLETED", true),
+
+		StorageRoot:   getEnv("STORAGE_ROOT", "./data/attachments"),
+		MaxUploadSize: getEnvInt64("MAX_UPLOAD_SIZE", 10<<20),
+
+		TrashRetention:     getEnvDuration("TRASH_RETENTION", 30*24*time.Hour),
+		TrashPurgeInterval: getEnvDuration("TRASH_PURGE_INTERVAL", time.Hour),
 	}
 }
 
@@ -37,6 +57,30 @@ func getEnv(key, defaultValue string) string {
 	return value
 }
 
+func getEnvBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvInt64(key string, defaultValue int64) int64 {
+	value, err := strconv.ParseInt(os.Getenv(key), 10, 64)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
 func (c *Config) GetDBConnectionString() string {
 	return fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"todo-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AttachmentHandler struct {
+	attachmentService *service.AttachmentService
+}
+
+func NewAttachmentHandler(attachmentService *service.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{attachmentService: attachmentService}
+}
+
+func (h *AttachmentHandler) GetAttachments(c *gin.Context) {
+	taskID := c.Param("id")
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	attachments, err := h.attachmentService.GetAttachments(taskID, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, attachments)
+}
+
+// UploadAttachment streams the "file" part of a multipart request straight to
+// the attachment service instead of buffering the whole form.
+func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
+	taskID := c.Param("id")
+
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Multipart form expected"})
+		return
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+			return
+		}
+
+		if part.FormName() != "file" {
+			continue
+		}
+
+		userID, _ := c.Get("user_id")
+		workspaceID, _ := c.Get("workspace_id")
+
+		attachment, err := h.attachmentService.Upload(taskID, part.FileName(), part, userID.(string), workspaceID.(string))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusCreated, attachment)
+		return
+	}
+
+	c.JSON(http.StatusBadRequest, gin.H{"error": "File is required"})
+}
+
+func (h *AttachmentHandler) DownloadAttachment(c *gin.Context) {
+	taskID := c.Param("id")
+	id := c.Param("attachment_id")
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	attachment, content, err := h.attachmentService.OpenAttachment(taskID, id, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
+	taskID := c.Param("id")
+	id := c.Param("attachment_id")
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	err := h.attachmentService.DeleteAttachment(taskID, id, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Attachment successfully deleted"})
+}
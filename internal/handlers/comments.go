@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"net/http"
+	"todo-api/internal/models"
+	"todo-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CommentHandler struct {
+	commentService *service.CommentService
+}
+
+func NewCommentHandler(commentService *service.CommentService) *CommentHandler {
+	return &CommentHandler{commentService: commentService}
+}
+
+func (h *CommentHandler) GetComments(c *gin.Context) {
+	taskID := c.Param("id")
+
+	var page models.PageQuery
+	if err := c.ShouldBindQuery(&page); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	comments, err := h.commentService.GetComments(taskID, page, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, comments)
+}
+
+func (h *CommentHandler) CreateComment(c *gin.Context) {
+	taskID := c.Param("id")
+
+	var req models.CommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	comment, err := h.commentService.CreateComment(taskID, req.Body, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, comment)
+}
+
+func (h *CommentHandler) UpdateComment(c *gin.Context) {
+	taskID := c.Param("id")
+	id := c.Param("comment_id")
+
+	var req models.CommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	comment, err := h.commentService.UpdateComment(taskID, id, req.Body, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
+
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	taskID := c.Param("id")
+	id := c.Param("comment_id")
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	err := h.commentService.DeleteComment(taskID, id, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Comment successfully deleted"})
+}
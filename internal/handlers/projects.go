@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"net/http"
+	"todo-api/internal/models"
+	"todo-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProjectHandler struct {
+	projectService *service.ProjectService
+	taskService    *service.TaskService
+}
+
+func NewProjectHandler(projectService *service.ProjectService, taskService *service.TaskService) *ProjectHandler {
+	return &ProjectHandler{
+		projectService: projectService,
+		taskService:    taskService,
+	}
+}
+
+func (h *ProjectHandler) GetProjects(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	projects, err := h.projectService.GetProjects(userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error while fetching projects"})
+		return
+	}
+
+	c.JSON(http.StatusOK, projects)
+}
+
+func (h *ProjectHandler) GetProject(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	project, err := h.projectService.GetProject(id, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+func (h *ProjectHandler) GetProjectTasks(c *gin.Context) {
+	id := c.Param("id")
+
+	var query models.TaskQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	if _, err := h.projectService.GetProject(id, userID.(string), workspaceID.(string)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	query.ProjectID = id
+
+	tasks, err := h.taskService.GetAllTasks(query, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+func (h *ProjectHandler) CreateProject(c *gin.Context) {
+	var req models.CreateProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	project, err := h.projectService.CreateProject(req, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, project)
+}
+
+func (h *ProjectHandler) UpdateProject(c *gin.Context) {
+	id := c.Param("id")
+
+	var req models.UpdateProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	project, err := h.projectService.UpdateProject(id, req, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+func (h *ProjectHandler) DeleteProject(c *gin.Context) {
+	id := c.Param("id")
+
+	var query models.DeleteProjectQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	err := h.projectService.DeleteProject(id, query.Mode, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Project successfully deleted"})
+}
+
+func (h *ProjectHandler) GetSharedProjects(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	projects, err := h.projectService.GetSharedProjects(userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error while fetching projects"})
+		return
+	}
+
+	c.JSON(http.StatusOK, projects)
+}
+
+func (h *ProjectHandler) ArchiveProjectTasks(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	result, err := h.taskService.ArchiveProjectTasks(id, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *ProjectHandler) GetWorkflow(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	workflow, err := h.projectService.GetWorkflow(id, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusNotFound), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, workflow)
+}
+
+func (h *ProjectHandler) UpdateWorkflow(c *gin.Context) {
+	id := c.Param("id")
+
+	var req models.UpdateWorkflowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	workflow, err := h.projectService.UpdateWorkflow(id, req, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, workflow)
+}
+
+func (h *ProjectHandler) GetMembers(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	members, err := h.projectService.GetMembers(id, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+func (h *ProjectHandler) AddMember(c *gin.Context) {
+	id := c.Param("id")
+
+	var req models.AddMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	member, err := h.projectService.AddMember(id, req, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, member)
+}
+
+func (h *ProjectHandler) UpdateMember(c *gin.Context) {
+	id := c.Param("id")
+	memberID := c.Param("user_id")
+
+	var req models.UpdateMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	member, err := h.projectService.UpdateMember(id, memberID, req, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, member)
+}
+
+func (h *ProjectHandler) RemoveMember(c *gin.Context) {
+	id := c.Param("id")
+	memberID := c.Param("user_id")
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	err := h.projectService.RemoveMember(id, memberID, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member successfully removed"})
+}
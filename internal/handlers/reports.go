@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+	"todo-api/internal/models"
+	"todo-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReportHandler struct {
+	reportService *service.ReportService
+}
+
+func NewReportHandler(reportService *service.ReportService) *ReportHandler {
+	return &ReportHandler{reportService: reportService}
+}
+
+func (h *ReportHandler) GetEstimateReport(c *gin.Context) {
+	var query models.EstimateReportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	report, err := h.reportService.GetEstimateReport(query, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
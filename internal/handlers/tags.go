@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"net/http"
+	"todo-api/internal/models"
+	"todo-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagHandler struct {
+	tagService *service.TagService
+}
+
+func NewTagHandler(tagService *service.TagService) *TagHandler {
+	return &TagHandler{tagService: tagService}
+}
+
+func (h *TagHandler) GetTags(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	tags, err := h.tagService.GetTags(userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error while fetching tags"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
+
+func (h *TagHandler) GetTag(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	tag, err := h.tagService.GetTag(id, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
+
+func (h *TagHandler) CreateTag(c *gin.Context) {
+	var req models.CreateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	tag, err := h.tagService.CreateTag(req, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, tag)
+}
+
+func (h *TagHandler) UpdateTag(c *gin.Context) {
+	id := c.Param("id")
+
+	var req models.UpdateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	tag, err := h.tagService.UpdateTag(id, req, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
+
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	err := h.tagService.DeleteTag(id, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Tag successfully deleted"})
+}
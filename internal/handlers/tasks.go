@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"todo-api/internal/models"
 	"todo-api/internal/service"
@@ -17,19 +18,60 @@ func NewTaskHandler(taskService *service.TaskService) *TaskHandler {
 }
 
 func (h *TaskHandler) GetTasks(c *gin.Context) {
-	tasks, err := h.taskService.GetAllTasks()
+	var query models.TaskQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	tasks, err := h.taskService.GetAllTasks(query, userID.(string), workspaceID.(string))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error while fetching tasks"})
+		status := errorStatus(err, http.StatusInternalServerError)
+		if status == http.StatusInternalServerError {
+			c.JSON(status, gin.H{"error": "Error while fetching tasks"})
+			return
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, tasks)
 }
 
+func (h *TaskHandler) SearchTasks(c *gin.Context) {
+	var query models.TaskSearchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	results, err := h.taskService.SearchTasks(query, userID.(string), workspaceID.(string))
+	if err != nil {
+		status := errorStatus(err, http.StatusInternalServerError)
+		if status == http.StatusInternalServerError {
+			c.JSON(status, gin.H{"error": "Error while searching tasks"})
+			return
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
 func (h *TaskHandler) GetTask(c *gin.Context) {
 	id := c.Param("id")
 
-	task, err := h.taskService.GetTask(id)
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	task, err := h.taskService.GetTask(id, userID.(string), workspaceID.(string))
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
@@ -46,10 +88,11 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 	}
 
 	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
 
-	task, err := h.taskService.CreateTask(req.Title, req.Description, userID.(string))
+	task, err := h.taskService.CreateTask(req, userID.(string), workspaceID.(string))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -66,10 +109,11 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 	}
 
 	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
 
-	task, err := h.taskService.UpdateTask(id, req, userID.(string))
+	task, err := h.taskService.UpdateTask(id, req, userID.(string), workspaceID.(string))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -80,8 +124,9 @@ func (h *TaskHandler) DeleteTask(c *gin.Context) {
 	id := c.Param("id")
 
 	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
 
-	err := h.taskService.DeleteTask(id, userID.(string))
+	err := h.taskService.DeleteTask(id, userID.(string), workspaceID.(string))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -89,3 +134,371 @@ func (h *TaskHandler) DeleteTask(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Task successfully deleted"})
 }
+
+func (h *TaskHandler) ArchiveTask(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	task, err := h.taskService.ArchiveTask(id, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+func (h *TaskHandler) UnarchiveTask(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	task, err := h.taskService.UnarchiveTask(id, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+func (h *TaskHandler) GetTrash(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	tasks, err := h.taskService.GetTrash(userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+func (h *TaskHandler) RestoreTask(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	task, err := h.taskService.RestoreTask(id, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+func (h *TaskHandler) PurgeTask(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	err := h.taskService.PurgeTask(id, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Task permanently deleted"})
+}
+
+func (h *TaskHandler) AttachTag(c *gin.Context) {
+	id := c.Param("id")
+	tagID := c.Param("tag_id")
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	err := h.taskService.AttachTag(id, tagID, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Tag successfully attached"})
+}
+
+func (h *TaskHandler) DetachTag(c *gin.Context) {
+	id := c.Param("id")
+	tagID := c.Param("tag_id")
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	err := h.taskService.DetachTag(id, tagID, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Tag successfully detached"})
+}
+
+func (h *TaskHandler) CreateSubtask(c *gin.Context) {
+	id := c.Param("id")
+
+	var req models.CreateTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	task, err := h.taskService.CreateSubtask(id, req, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, task)
+}
+
+func (h *TaskHandler) GetTaskTree(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	tree, err := h.taskService.GetTaskTree(id, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tree)
+}
+
+func (h *TaskHandler) AddDependency(c *gin.Context) {
+	id := c.Param("id")
+
+	var req models.AddDependencyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	task, err := h.taskService.AddDependency(id, req.DependsOnID, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+func (h *TaskHandler) RemoveDependency(c *gin.Context) {
+	id := c.Param("id")
+	dependsOnID := c.Param("depends_on_id")
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	task, err := h.taskService.RemoveDependency(id, dependsOnID, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+func (h *TaskHandler) GetOccurrences(c *gin.Context) {
+	id := c.Param("id")
+
+	var query models.OccurrenceQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	occurrences, err := h.taskService.GetOccurrences(id, query.Count, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, occurrences)
+}
+
+func (h *TaskHandler) AssignTask(c *gin.Context) {
+	id := c.Param("id")
+
+	var req models.AssignTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	task, err := h.taskService.AssignTask(id, req.UserID, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+func (h *TaskHandler) UnassignTask(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	task, err := h.taskService.AssignTask(id, "", userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+func (h *TaskHandler) TransitionTask(c *gin.Context) {
+	id := c.Param("id")
+
+	var req models.TransitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	task, err := h.taskService.TransitionTask(id, req.Status, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+func (h *TaskHandler) MoveTask(c *gin.Context) {
+	id := c.Param("id")
+
+	var req models.MoveTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	task, err := h.taskService.MoveTask(id, req, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// BulkUpdateTasks responds with 400 and the per-operation report when the
+// operations were rolled back.
+func (h *TaskHandler) BulkUpdateTasks(c *gin.Context) {
+	var req models.BulkTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	response, err := h.taskService.BulkUpdateTasks(req, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !response.Applied {
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *TaskHandler) GetBoard(c *gin.Context) {
+	var query models.BoardQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	board, err := h.taskService.GetBoard(query, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, board)
+}
+
+func (h *TaskHandler) GetTransitions(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	transitions, err := h.taskService.GetTransitions(id, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, transitions)
+}
+
+func (h *TaskHandler) GetHistory(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	history, err := h.taskService.GetHistory(id, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// errorStatus answers workflow violations with 422, unparsable task queries
+// with 400 and other errors with fallback.
+func errorStatus(err error, fallback int) int {
+	var workflowErr *service.WorkflowError
+	if errors.As(err, &workflowErr) {
+		return http.StatusUnprocessableEntity
+	}
+
+	var queryErr *service.QueryError
+	if errors.As(err, &queryErr) {
+		return http.StatusBadRequest
+	}
+
+	return fallback
+}
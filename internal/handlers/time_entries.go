@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"net/http"
+	"todo-api/internal/models"
+	"todo-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TimeHandler struct {
+	timeService *service.TimeService
+}
+
+func NewTimeHandler(timeService *service.TimeService) *TimeHandler {
+	return &TimeHandler{timeService: timeService}
+}
+
+func (h *TimeHandler) StartTimer(c *gin.Context) {
+	taskID := c.Param("id")
+
+	var req models.StartTimerRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+			return
+		}
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	entry, err := h.timeService.StartTimer(taskID, req.Note, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+func (h *TimeHandler) StopTimer(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	entry, err := h.timeService.StopTimer(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+func (h *TimeHandler) GetTimer(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	entry, err := h.timeService.GetRunningTimer(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+func (h *TimeHandler) GetTimeEntries(c *gin.Context) {
+	taskID := c.Param("id")
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	entries, err := h.timeService.GetTimeEntries(taskID, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+func (h *TimeHandler) CreateTimeEntry(c *gin.Context) {
+	taskID := c.Param("id")
+
+	var req models.TimeEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	entry, err := h.timeService.CreateTimeEntry(taskID, req, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+func (h *TimeHandler) UpdateTimeEntry(c *gin.Context) {
+	taskID := c.Param("id")
+	id := c.Param("entry_id")
+
+	var req models.UpdateTimeEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	entry, err := h.timeService.UpdateTimeEntry(taskID, id, req, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+func (h *TimeHandler) DeleteTimeEntry(c *gin.Context) {
+	taskID := c.Param("id")
+	id := c.Param("entry_id")
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	err := h.timeService.DeleteTimeEntry(taskID, id, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Time entry successfully deleted"})
+}
+
+func (h *TimeHandler) GetTotals(c *gin.Context) {
+	var query models.TimeTotalsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	totals, err := h.timeService.GetTotals(query, userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, totals)
+}
@@ -17,7 +17,9 @@ func NewUserHandler(userService *service.UserService) *UserHandler {
 }
 
 func (h *UserHandler) GetUsers(c *gin.Context) {
-	users, err := h.userService.GetAllUsers()
+	workspaceID, _ := c.Get("workspace_id")
+
+	users, err := h.userService.GetAllUsers(workspaceID.(string))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error while fetching users"})
 		return
@@ -29,7 +31,9 @@ func (h *UserHandler) GetUsers(c *gin.Context) {
 func (h *UserHandler) GetUser(c *gin.Context) {
 	id := c.Param("id")
 
-	user, err := h.userService.GetUser(id)
+	workspaceID, _ := c.Get("workspace_id")
+
+	user, err := h.userService.GetUser(id, workspaceID.(string))
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
@@ -45,7 +49,9 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 		return
 	}
 
-	user, err := h.userService.CreateUser(req.Name, req.Email, req.Password)
+	workspaceID, _ := c.Get("workspace_id")
+
+	user, err := h.userService.CreateUser(req.Name, req.Email, req.Password, workspaceID.(string))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -63,7 +69,9 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
-	user, err := h.userService.UpdateUser(id, req)
+	userID, _ := c.Get("user_id")
+
+	user, err := h.userService.UpdateUser(id, req, userID.(string))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -75,7 +83,9 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 func (h *UserHandler) DeleteUser(c *gin.Context) {
 	id := c.Param("id")
 
-	err := h.userService.DeleteUser(id)
+	userID, _ := c.Get("user_id")
+
+	err := h.userService.DeleteUser(id, userID.(string))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
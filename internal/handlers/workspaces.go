@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"net/http"
+	"todo-api/internal/models"
+	"todo-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WorkspaceHandler struct {
+	workspaceService *service.WorkspaceService
+	authService      *service.AuthService
+}
+
+func NewWorkspaceHandler(workspaceService *service.WorkspaceService, authService *service.AuthService) *WorkspaceHandler {
+	return &WorkspaceHandler{
+		workspaceService: workspaceService,
+		authService:      authService,
+	}
+}
+
+func (h *WorkspaceHandler) GetWorkspaces(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	workspaceID, _ := c.Get("workspace_id")
+
+	workspaces, err := h.workspaceService.GetWorkspaces(userID.(string), workspaceID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error while fetching workspaces"})
+		return
+	}
+
+	c.JSON(http.StatusOK, workspaces)
+}
+
+func (h *WorkspaceHandler) CreateWorkspace(c *gin.Context) {
+	var req models.CreateWorkspaceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+
+	workspace, err := h.workspaceService.CreateWorkspace(req, userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, workspace)
+}
+
+func (h *WorkspaceHandler) SwitchWorkspace(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, _ := c.Get("user_id")
+
+	token, err := h.authService.SwitchWorkspace(userID.(string), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"token": token})
+}
+
+func (h *WorkspaceHandler) GetMembers(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, _ := c.Get("user_id")
+
+	members, err := h.workspaceService.GetMembers(id, userID.(string))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+func (h *WorkspaceHandler) InviteMember(c *gin.Context) {
+	id := c.Param("id")
+
+	var req models.InviteWorkspaceMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bad request"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+
+	invitation, err := h.workspaceService.InviteMember(id, req, userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, invitation)
+}
+
+func (h *WorkspaceHandler) GetInvitations(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	invitations, err := h.workspaceService.GetInvitations(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error while fetching invitations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+func (h *WorkspaceHandler) AcceptInvitation(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, _ := c.Get("user_id")
+
+	workspace, err := h.workspaceService.AcceptInvitation(id, userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, workspace)
+}
+
+func (h *WorkspaceHandler) DeclineInvitation(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, _ := c.Get("user_id")
+
+	err := h.workspaceService.DeclineInvitation(id, userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Invitation successfully declined"})
+}
@@ -40,8 +40,11 @@ func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 		}
 
 		if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-			if userID, ok := claims["user_id"].(string); ok {
+			userID, hasUser := claims["user_id"].(string)
+			workspaceID, hasWorkspace := claims["workspace_id"].(string)
+			if hasUser && hasWorkspace {
 				c.Set("user_id", userID)
+				c.Set("workspace_id", workspaceID)
 				c.Set("email", claims["email"])
 			} else {
 				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invlaid token claims"})
@@ -0,0 +1,12 @@
+-- +goose Up
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS due_at TIMESTAMPTZ;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS due_has_time BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS due_timezone VARCHAR(64) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_tasks_due_at ON tasks(due_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tasks_due_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS due_timezone;
+ALTER TABLE tasks DROP COLUMN IF EXISTS due_has_time;
+ALTER TABLE tasks DROP COLUMN IF EXISTS due_at;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS recurrence VARCHAR(255) NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE tasks DROP COLUMN IF EXISTS recurrence;
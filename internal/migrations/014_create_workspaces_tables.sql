@@ -0,0 +1,52 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS workspaces (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    owner_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS workspace_members (
+    workspace_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (workspace_id, user_id),
+    FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CHECK (role IN ('owner', 'member'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_workspace_members_user_id ON workspace_members(user_id);
+
+-- Every existing user gets a personal workspace holding their projects and tasks.
+INSERT INTO workspaces (id, name, owner_id)
+SELECT gen_random_uuid()::text, name || '''s workspace', id FROM users;
+
+INSERT INTO workspace_members (workspace_id, user_id, role)
+SELECT id, owner_id, 'owner' FROM workspaces;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS workspace_id VARCHAR(36) REFERENCES workspaces(id) ON DELETE SET NULL;
+UPDATE users u SET workspace_id = w.id FROM workspaces w WHERE w.owner_id = u.id;
+
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS workspace_id VARCHAR(36) REFERENCES workspaces(id) ON DELETE CASCADE;
+UPDATE projects p SET workspace_id = u.workspace_id FROM users u WHERE u.id = p.user_id;
+ALTER TABLE projects ALTER COLUMN workspace_id SET NOT NULL;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS workspace_id VARCHAR(36) REFERENCES workspaces(id) ON DELETE CASCADE;
+UPDATE tasks t SET workspace_id = u.workspace_id FROM users u WHERE u.id = t.user_id;
+ALTER TABLE tasks ALTER COLUMN workspace_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_projects_workspace_id ON projects(workspace_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_workspace_id ON tasks(workspace_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tasks_workspace_id;
+DROP INDEX IF EXISTS idx_projects_workspace_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS workspace_id;
+ALTER TABLE projects DROP COLUMN IF EXISTS workspace_id;
+ALTER TABLE users DROP COLUMN IF EXISTS workspace_id;
+DROP INDEX IF EXISTS idx_workspace_members_user_id;
+DROP TABLE IF EXISTS workspace_members;
+DROP TABLE IF EXISTS workspaces;
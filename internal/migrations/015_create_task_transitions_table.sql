@@ -0,0 +1,20 @@
+-- +goose Up
+-- Statuses used to be accepted verbatim; anything outside the workflow starts over as New.
+UPDATE tasks SET status = 'New' WHERE status NOT IN ('New', 'In progress', 'Finished');
+
+CREATE TABLE IF NOT EXISTS task_transitions (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_transitions_task_id ON task_transitions(task_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_task_transitions_task_id;
+DROP TABLE IF EXISTS task_transitions;
@@ -0,0 +1,68 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS workflow_statuses (
+    id VARCHAR(36) PRIMARY KEY,
+    project_id VARCHAR(36) REFERENCES projects(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    position INTEGER NOT NULL,
+    is_done BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Statuses without a project make up the default workflow.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_workflow_statuses_project_name ON workflow_statuses(COALESCE(project_id, ''), name);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_workflow_statuses_project_position ON workflow_statuses(COALESCE(project_id, ''), position);
+
+CREATE TABLE IF NOT EXISTS workflow_transitions (
+    from_status_id VARCHAR(36) NOT NULL,
+    to_status_id VARCHAR(36) NOT NULL,
+    PRIMARY KEY (from_status_id, to_status_id),
+    FOREIGN KEY (from_status_id) REFERENCES workflow_statuses(id) ON DELETE CASCADE,
+    FOREIGN KEY (to_status_id) REFERENCES workflow_statuses(id) ON DELETE CASCADE
+);
+
+INSERT INTO workflow_statuses (id, project_id, name, position, is_done) VALUES
+    ('default-new', NULL, 'New', 0, FALSE),
+    ('default-in-progress', NULL, 'In progress', 1, FALSE),
+    ('default-finished', NULL, 'Finished', 2, TRUE);
+
+INSERT INTO workflow_transitions (from_status_id, to_status_id) VALUES
+    ('default-new', 'default-in-progress'),
+    ('default-in-progress', 'default-new'),
+    ('default-in-progress', 'default-finished'),
+    ('default-finished', 'default-new'),
+    ('default-finished', 'default-in-progress');
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS status_id VARCHAR(36) REFERENCES workflow_statuses(id);
+
+UPDATE tasks SET status_id = CASE status
+    WHEN 'In progress' THEN 'default-in-progress'
+    WHEN 'Finished' THEN 'default-finished'
+    ELSE 'default-new'
+END;
+
+ALTER TABLE tasks ALTER COLUMN status_id SET NOT NULL;
+
+DROP INDEX IF EXISTS idx_tasks_status;
+ALTER TABLE tasks DROP COLUMN IF EXISTS status;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_status_id ON tasks(status_id);
+
+-- +goose Down
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'New';
+
+UPDATE tasks t SET status = CASE
+    WHEN ws.is_done THEN 'Finished'
+    WHEN ws.position = 0 THEN 'New'
+    ELSE 'In progress'
+END
+FROM workflow_statuses ws
+WHERE ws.id = t.status_id;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks(status);
+
+DROP INDEX IF EXISTS idx_tasks_status_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS status_id;
+DROP TABLE IF EXISTS workflow_transitions;
+DROP INDEX IF EXISTS idx_workflow_statuses_project_position;
+DROP INDEX IF EXISTS idx_workflow_statuses_project_name;
+DROP TABLE IF EXISTS workflow_statuses;
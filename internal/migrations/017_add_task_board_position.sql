@@ -0,0 +1,20 @@
+-- +goose Up
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS board_position DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+-- Existing tasks are laid out in each column in the order they were created.
+UPDATE tasks t SET board_position = ranked.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY project_id, status_id ORDER BY created_at, id) * 1024 AS position
+    FROM tasks
+) ranked
+WHERE ranked.id = t.id;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_board ON tasks(project_id, status_id, board_position);
+
+-- A limit of 0 leaves the column unlimited.
+ALTER TABLE workflow_statuses ADD COLUMN IF NOT EXISTS wip_limit INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE workflow_statuses DROP COLUMN IF EXISTS wip_limit;
+DROP INDEX IF EXISTS idx_tasks_board;
+ALTER TABLE tasks DROP COLUMN IF EXISTS board_position;
@@ -0,0 +1,17 @@
+-- +goose Up
+-- History outlives the task it describes, so task_id carries no foreign key.
+CREATE TABLE IF NOT EXISTS task_history (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    changes JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_history_task_id ON task_history(task_id, created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_task_history_task_id;
+DROP TABLE IF EXISTS task_history;
@@ -0,0 +1,23 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS time_entries (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    started_at TIMESTAMP NOT NULL,
+    stopped_at TIMESTAMP,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_time_entries_task_id ON time_entries(task_id, started_at);
+CREATE INDEX IF NOT EXISTS idx_time_entries_user_id ON time_entries(user_id, started_at);
+-- A user can only have one running timer at a time.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_time_entries_running ON time_entries(user_id) WHERE stopped_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_time_entries_running;
+DROP INDEX IF EXISTS idx_time_entries_user_id;
+DROP INDEX IF EXISTS idx_time_entries_task_id;
+DROP TABLE IF EXISTS time_entries;
@@ -0,0 +1,17 @@
+-- +goose Up
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS estimate_unit VARCHAR(10) NOT NULL DEFAULT 'hours';
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS estimate DOUBLE PRECISION;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP;
+
+-- Tasks finished before completion times were kept count as finished at
+-- their last transition, or at their last update without one.
+UPDATE tasks SET completed_at = COALESCE(
+    (SELECT MAX(tt.created_at) FROM task_transitions tt WHERE tt.task_id = tasks.id),
+    updated_at
+)
+WHERE status_id IN (SELECT id FROM workflow_statuses WHERE is_done);
+
+-- +goose Down
+ALTER TABLE tasks DROP COLUMN IF EXISTS completed_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS estimate;
+ALTER TABLE projects DROP COLUMN IF EXISTS estimate_unit;
@@ -0,0 +1,30 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS views (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    workspace_id VARCHAR(36) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    filter JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_views_user_id ON views(user_id, workspace_id);
+
+CREATE TABLE IF NOT EXISTS view_shares (
+    view_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    PRIMARY KEY (view_id, user_id),
+    FOREIGN KEY (view_id) REFERENCES views(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_view_shares_user_id ON view_shares(user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_view_shares_user_id;
+DROP TABLE IF EXISTS view_shares;
+DROP INDEX IF EXISTS idx_views_user_id;
+DROP TABLE IF EXISTS views;
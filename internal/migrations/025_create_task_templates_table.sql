@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS task_templates (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    workspace_id VARCHAR(36) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    shared BOOLEAN NOT NULL DEFAULT FALSE,
+    task JSONB NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_templates_workspace_id ON task_templates(workspace_id, user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_task_templates_workspace_id;
+DROP TABLE IF EXISTS task_templates;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Entries without a user were made by the server itself, such as purges of
+-- expired trash.
+ALTER TABLE task_history ALTER COLUMN user_id DROP NOT NULL;
+
+-- +goose Down
+DELETE FROM task_history WHERE user_id IS NULL;
+ALTER TABLE task_history ALTER COLUMN user_id SET NOT NULL;
@@ -0,0 +1,36 @@
+-- +goose Up
+ALTER TABLE tags ADD COLUMN IF NOT EXISTS workspace_id VARCHAR(36) REFERENCES workspaces(id) ON DELETE CASCADE;
+
+-- Existing tags move to their owner's personal workspace, or the active one.
+UPDATE tags t SET workspace_id = (
+    SELECT w.id FROM workspaces w WHERE w.owner_id = t.user_id ORDER BY w.created_at LIMIT 1
+);
+UPDATE tags t SET workspace_id = u.workspace_id FROM users u WHERE u.id = t.user_id AND t.workspace_id IS NULL;
+DELETE FROM tags WHERE workspace_id IS NULL;
+ALTER TABLE tags ALTER COLUMN workspace_id SET NOT NULL;
+
+ALTER TABLE tags DROP CONSTRAINT IF EXISTS tags_user_id_name_key;
+ALTER TABLE tags ADD CONSTRAINT tags_workspace_id_user_id_name_key UNIQUE (workspace_id, user_id, name);
+
+-- Tags already used on tasks of other workspaces are copied into those
+-- workspaces, so each task keeps its tags.
+INSERT INTO tags (id, name, color, user_id, workspace_id)
+SELECT gen_random_uuid()::text, tg.name, tg.color, tg.user_id, used.workspace_id
+FROM tags tg
+JOIN (
+    SELECT DISTINCT tt.tag_id, t.workspace_id
+    FROM task_tags tt JOIN tasks t ON t.id = tt.task_id
+) used ON used.tag_id = tg.id
+WHERE used.workspace_id <> tg.workspace_id;
+
+UPDATE task_tags tt SET tag_id = copy.id
+FROM tags tg, tasks t, tags copy
+WHERE tg.id = tt.tag_id AND t.id = tt.task_id AND t.workspace_id <> tg.workspace_id
+    AND copy.workspace_id = t.workspace_id AND copy.user_id = tg.user_id AND copy.name = tg.name;
+
+CREATE INDEX IF NOT EXISTS idx_tags_workspace_id ON tags(workspace_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tags_workspace_id;
+ALTER TABLE tags DROP CONSTRAINT IF EXISTS tags_workspace_id_user_id_name_key;
+ALTER TABLE tags DROP COLUMN IF EXISTS workspace_id;
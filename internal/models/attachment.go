@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+	"todo-api/internal/repository"
+)
+
+type Attachment struct {
+	ID          string    `json:"id"`
+	TaskID      string    `json:"task_id"`
+	UserID      string    `json:"user_id"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Checksum    string    `json:"checksum"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (a *Attachment) ConvertToRepositoryAttachment() repository.Attachment {
+	return repository.Attachment{
+		ID:          a.ID,
+		TaskID:      a.TaskID,
+		UserID:      a.UserID,
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Checksum:    a.Checksum,
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
+func ConvertFromRepositoryAttachment(ra repository.Attachment) Attachment {
+	return Attachment{
+		ID:          ra.ID,
+		TaskID:      ra.TaskID,
+		UserID:      ra.UserID,
+		Filename:    ra.Filename,
+		ContentType: ra.ContentType,
+		Size:        ra.Size,
+		Checksum:    ra.Checksum,
+		CreatedAt:   ra.CreatedAt,
+	}
+}
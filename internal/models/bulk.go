@@ -0,0 +1,56 @@
+package models
+
+type BulkAction string
+
+const (
+	BulkUpdate BulkAction = "update"
+	BulkStatus BulkAction = "status"
+	BulkDelete BulkAction = "delete"
+	BulkMove   BulkAction = "move"
+)
+
+func (a BulkAction) IsValid() bool {
+	return a == BulkUpdate || a == BulkStatus || a == BulkDelete || a == BulkMove
+}
+
+type BulkTaskRequest struct {
+	Operations []BulkTaskOperation `json:"operations" binding:"required,min=1,max=100,dive"`
+}
+
+// BulkTaskOperation is one change of a bulk request. Update applies Fields
+// like PUT /tasks/:id, status transitions the task to Status, move puts it in
+// ProjectID (empty for the Inbox) and delete moves it to the trash.
+type BulkTaskOperation struct {
+	Op        BulkAction         `json:"op" binding:"required"`
+	TaskID    string             `json:"task_id" binding:"required"`
+	Fields    *UpdateTaskRequest `json:"fields"`
+	Status    TaskStatus         `json:"status"`
+	ProjectID string             `json:"project_id"`
+}
+
+type BulkResult string
+
+const (
+	BulkApplied    BulkResult = "applied"
+	BulkFailed     BulkResult = "failed"
+	BulkRolledBack BulkResult = "rolled_back"
+	BulkSkipped    BulkResult = "skipped"
+)
+
+// BulkTaskResponse reports the outcome of every operation in request order.
+// Operations are applied all together; when one fails, those before it are
+// rolled back and those after it are skipped.
+type BulkTaskResponse struct {
+	Applied bool             `json:"applied"`
+	Error   string           `json:"error,omitempty"`
+	Results []BulkTaskResult `json:"results"`
+}
+
+type BulkTaskResult struct {
+	Index  int        `json:"index"`
+	Op     BulkAction `json:"op"`
+	TaskID string     `json:"task_id"`
+	Result BulkResult `json:"result"`
+	Error  string     `json:"error,omitempty"`
+	Task   *Task      `json:"task,omitempty"`
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+	"todo-api/internal/repository"
+)
+
+type Comment struct {
+	ID        string    `json:"id"`
+	TaskID    string    `json:"task_id"`
+	AuthorID  string    `json:"author_id"`
+	Body      string    `json:"body"`
+	Edited    bool      `json:"edited"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CommentRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+type PageQuery struct {
+	Limit  int `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
+
+type CommentPage struct {
+	Comments []Comment `json:"comments"`
+	Total    int       `json:"total"`
+	Limit    int       `json:"limit"`
+	Offset   int       `json:"offset"`
+}
+
+func (c *Comment) ConvertToRepositoryComment() repository.Comment {
+	return repository.Comment{
+		ID:        c.ID,
+		TaskID:    c.TaskID,
+		UserID:    c.AuthorID,
+		Body:      c.Body,
+		Edited:    c.Edited,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+func ConvertFromRepositoryComment(rc repository.Comment) Comment {
+	return Comment{
+		ID:        rc.ID,
+		TaskID:    rc.TaskID,
+		AuthorID:  rc.UserID,
+		Body:      rc.Body,
+		Edited:    rc.Edited,
+		CreatedAt: rc.CreatedAt,
+		UpdatedAt: rc.UpdatedAt,
+	}
+}
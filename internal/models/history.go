@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+	"todo-api/internal/repository"
+)
+
+const (
+	HistoryActionCreated  = "created"
+	HistoryActionUpdated  = "updated"
+	HistoryActionDeleted  = "deleted"
+	HistoryActionRestored = "restored"
+	HistoryActionPurged   = "purged"
+)
+
+// TaskHistoryEntry records who changed a task and how. Fields without a value
+// before or after the change are reported as empty strings. Entries made by
+// the server itself, such as purges of expired trash, have no user.
+type TaskHistoryEntry struct {
+	ID        string        `json:"id"`
+	TaskID    string        `json:"task_id"`
+	UserID    string        `json:"user_id,omitempty"`
+	Action    string        `json:"action"`
+	Changes   []FieldChange `json:"changes"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type FieldChange struct {
+	Field    string `json:"field"`
+	OldValue string `json:"old"`
+	NewValue string `json:"new"`
+}
+
+func (e *TaskHistoryEntry) ConvertToRepositoryHistoryEntry() repository.TaskHistoryEntry {
+	changes := make([]repository.FieldChange, len(e.Changes))
+	for i, change := range e.Changes {
+		changes[i] = repository.FieldChange(change)
+	}
+
+	return repository.TaskHistoryEntry{
+		ID:        e.ID,
+		TaskID:    e.TaskID,
+		UserID:    e.UserID,
+		Action:    e.Action,
+		Changes:   changes,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+func ConvertFromRepositoryHistoryEntry(re repository.TaskHistoryEntry) TaskHistoryEntry {
+	changes := make([]FieldChange, len(re.Changes))
+	for i, change := range re.Changes {
+		changes[i] = FieldChange(change)
+	}
+
+	return TaskHistoryEntry{
+		ID:        re.ID,
+		TaskID:    re.TaskID,
+		UserID:    re.UserID,
+		Action:    re.Action,
+		Changes:   changes,
+		CreatedAt: re.CreatedAt,
+	}
+}
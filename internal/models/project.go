@@ -0,0 +1,144 @@
+package models
+
+import (
+	"time"
+	"todo-api/internal/repository"
+)
+
+const (
+	ProjectDeleteCascade = "cascade"
+	ProjectDeleteInbox   = "inbox"
+)
+
+// ProjectRole is the access level of a user on a project and its tasks.
+// Each role includes the permissions of the roles below it.
+type ProjectRole string
+
+const (
+	RoleViewer  ProjectRole = "viewer"
+	RoleEditor  ProjectRole = "editor"
+	RoleManager ProjectRole = "manager"
+	RoleOwner   ProjectRole = "owner"
+)
+
+var projectRoleRanks = map[ProjectRole]int{
+	RoleViewer:  1,
+	RoleEditor:  2,
+	RoleManager: 3,
+	RoleOwner:   4,
+}
+
+// IsValid reports whether r can be granted to a project member.
+func (r ProjectRole) IsValid() bool {
+	return r == RoleViewer || r == RoleEditor || r == RoleManager
+}
+
+// Allows reports whether r grants at least the permissions of required.
+func (r ProjectRole) Allows(required ProjectRole) bool {
+	return projectRoleRanks[r] >= projectRoleRanks[required]
+}
+
+// EstimateUnit is what task estimates in a project are counted in. Tasks in
+// the Inbox are estimated in hours.
+type EstimateUnit string
+
+const (
+	EstimateHours  EstimateUnit = "hours"
+	EstimatePoints EstimateUnit = "points"
+)
+
+func (u EstimateUnit) IsValid() bool {
+	return u == EstimateHours || u == EstimatePoints
+}
+
+// InboxProjectID selects tasks that do not belong to any project in task queries.
+const InboxProjectID = "inbox"
+
+type Project struct {
+	ID           string       `json:"id"`
+	Name         string       `json:"name"`
+	Description  string       `json:"description"`
+	UserID       string       `json:"user_id,omitempty"`
+	WorkspaceID  string       `json:"workspace_id"`
+	EstimateUnit EstimateUnit `json:"estimate_unit"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+type SharedProject struct {
+	Project
+	Role ProjectRole `json:"role"`
+}
+
+type ProjectMember struct {
+	ProjectID string      `json:"project_id"`
+	UserID    string      `json:"user_id"`
+	Role      ProjectRole `json:"role"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+type AddMemberRequest struct {
+	Email string      `json:"email" binding:"required,email"`
+	Role  ProjectRole `json:"role" binding:"required"`
+}
+
+type UpdateMemberRequest struct {
+	Role ProjectRole `json:"role" binding:"required"`
+}
+
+type CreateProjectRequest struct {
+	Name         string       `json:"name" binding:"required,max=255"`
+	Description  string       `json:"description"`
+	EstimateUnit EstimateUnit `json:"estimate_unit"`
+}
+
+type UpdateProjectRequest struct {
+	Name         string       `json:"name" binding:"max=255"`
+	Description  string       `json:"description"`
+	EstimateUnit EstimateUnit `json:"estimate_unit"`
+}
+
+type DeleteProjectQuery struct {
+	Mode string `form:"mode"`
+}
+
+func (p *Project) ConvertToRepositoryProject() repository.Project {
+	return repository.Project{
+		ID:           p.ID,
+		Name:         p.Name,
+		Description:  p.Description,
+		UserID:       p.UserID,
+		WorkspaceID:  p.WorkspaceID,
+		EstimateUnit: string(p.EstimateUnit),
+		CreatedAt:    p.CreatedAt,
+	}
+}
+
+func ConvertFromRepositoryProject(rp repository.Project) Project {
+	return Project{
+		ID:           rp.ID,
+		Name:         rp.Name,
+		Description:  rp.Description,
+		UserID:       rp.UserID,
+		WorkspaceID:  rp.WorkspaceID,
+		EstimateUnit: EstimateUnit(rp.EstimateUnit),
+		CreatedAt:    rp.CreatedAt,
+	}
+}
+
+func (m *ProjectMember) ConvertToRepositoryProjectMember() repository.ProjectMember {
+	return repository.ProjectMember{
+		ProjectID: m.ProjectID,
+		UserID:    m.UserID,
+		Role:      string(m.Role),
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+func ConvertFromRepositoryProjectMember(rm repository.ProjectMember) ProjectMember {
+	return ProjectMember{
+		ProjectID: rm.ProjectID,
+		UserID:    rm.UserID,
+		Role:      ProjectRole(rm.Role),
+		CreatedAt: rm.CreatedAt,
+	}
+}
@@ -0,0 +1,30 @@
+package models
+
+// EstimateReportQuery selects finished tasks by when they were completed.
+// From is inclusive and To is exclusive; both accept a date or an RFC 3339
+// timestamp.
+type EstimateReportQuery struct {
+	From      string `form:"from"`
+	To        string `form:"to"`
+	ProjectID string `form:"project_id"`
+	UserID    string `form:"user_id"`
+}
+
+type EstimateReport struct {
+	Rows []EstimateReportRow `json:"rows"`
+}
+
+// EstimateReportRow compares the estimates of the tasks a user finished in
+// one week with the time they actually took. Week is the Monday the week
+// starts on. A task's actual time is the time tracked on it, or the time
+// from creation to completion when none was tracked.
+type EstimateReportRow struct {
+	UserID       string       `json:"user_id"`
+	Week         string       `json:"week"`
+	Unit         EstimateUnit `json:"unit"`
+	Tasks        int          `json:"tasks"`
+	TrackedTasks int          `json:"tracked_tasks"`
+	Estimate     float64      `json:"estimate"`
+	ActualHours  float64      `json:"actual_hours"`
+	HoursPerUnit float64      `json:"hours_per_unit"`
+}
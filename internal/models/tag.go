@@ -0,0 +1,41 @@
+package models
+
+import "todo-api/internal/repository"
+
+type Tag struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	UserID      string `json:"user_id,omitempty"`
+	WorkspaceID string `json:"workspace_id,omitempty"`
+}
+
+type CreateTagRequest struct {
+	Name  string `json:"name" binding:"required,max=50"`
+	Color string `json:"color" binding:"max=20"`
+}
+
+type UpdateTagRequest struct {
+	Name  string `json:"name" binding:"max=50"`
+	Color string `json:"color" binding:"max=20"`
+}
+
+func (t *Tag) ConvertToRepositoryTag() repository.Tag {
+	return repository.Tag{
+		ID:          t.ID,
+		Name:        t.Name,
+		Color:       t.Color,
+		UserID:      t.UserID,
+		WorkspaceID: t.WorkspaceID,
+	}
+}
+
+func ConvertFromRepositoryTag(rt repository.Tag) Tag {
+	return Tag{
+		ID:          rt.ID,
+		Name:        rt.Name,
+		Color:       rt.Color,
+		UserID:      rt.UserID,
+		WorkspaceID: rt.WorkspaceID,
+	}
+}
@@ -1,50 +1,343 @@
 package models
 
-import "todo-api/internal/repository"
+import (
+	"time"
+	"todo-api/internal/repository"
+)
 
+// TaskStatus is the name of a status in the workflow of the task's project.
 type TaskStatus string
 
+type TaskPriority string
+
+const (
+	PriorityNone   TaskPriority = "none"
+	PriorityLow    TaskPriority = "low"
+	PriorityMedium TaskPriority = "medium"
+	PriorityHigh   TaskPriority = "high"
+	PriorityUrgent TaskPriority = "urgent"
+)
+
+var taskPriorities = []TaskPriority{PriorityNone, PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent}
+
+const (
+	AssigneeMe   = "me"
+	AssigneeNone = "none"
+)
+
 const (
-	StatusNew        TaskStatus = "New"
-	StatusInProgress TaskStatus = "In progress"
-	StatusCompleted  TaskStatus = "Finished"
+	TagModeAll = "all"
+	TagModeAny = "any"
+)
+
+const (
+	DueDateLayout = "2006-01-02"
+	DueTimeLayout = "15:04"
 )
 
 type Task struct {
-	ID          string     `json:"id"`
-	Title       string     `json:"title"`
-	Description string     `json:"description"`
-	Status      TaskStatus `json:"status"`
-	UserID      string     `json:"user_id,omitempty"`
+	ID          string       `json:"id"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Status      TaskStatus   `json:"status"`
+	StatusID    string       `json:"status_id"`
+	Done        bool         `json:"done"`
+	Position    float64      `json:"position"`
+	UserID      string       `json:"user_id,omitempty"`
+	DueDate     string       `json:"due_date,omitempty"`
+	DueTime     string       `json:"due_time,omitempty"`
+	DueTimezone string       `json:"due_timezone,omitempty"`
+	DueAt       *time.Time   `json:"due_at,omitempty"`
+	Overdue     bool         `json:"overdue"`
+	Priority    TaskPriority `json:"priority"`
+	Estimate    *float64     `json:"estimate,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+	CompletedAt *time.Time   `json:"completed_at,omitempty"`
+	Tags        []string     `json:"tags"`
+	ParentID    string       `json:"parent_id,omitempty"`
+	BlockedBy   []string     `json:"blocked_by"`
+	Blocks      []string     `json:"blocks"`
+	Recurrence  string       `json:"recurrence,omitempty"`
+	ProjectID   string       `json:"project_id,omitempty"`
+	AssigneeID  string       `json:"assignee_id,omitempty"`
+	WorkspaceID string       `json:"workspace_id"`
+	Archived    bool         `json:"archived"`
+	ArchivedAt  *time.Time   `json:"archived_at,omitempty"`
+	DeletedAt   *time.Time   `json:"deleted_at,omitempty"`
+}
+
+type TaskProgress struct {
+	Completed int `json:"completed"`
+	Total     int `json:"total"`
+}
+
+type TaskTree struct {
+	Task
+	Progress TaskProgress `json:"progress"`
+	Subtasks []TaskTree   `json:"subtasks"`
 }
 
 type CreateTaskRequest struct {
-	Title       string `json:"title" binding:"required"`
-	Description string `json:"description"`
+	Title       string       `json:"title" binding:"required"`
+	Description string       `json:"description"`
+	DueDate     string       `json:"due_date"`
+	DueTime     string       `json:"due_time"`
+	DueTimezone string       `json:"due_timezone"`
+	Priority    TaskPriority `json:"priority"`
+	Estimate    *float64     `json:"estimate" binding:"omitempty,min=0"`
+	ParentID    string       `json:"parent_id"`
+	Recurrence  string       `json:"recurrence"`
+	ProjectID   string       `json:"project_id"`
+	AssigneeID  string       `json:"assignee_id"`
 }
 
+// UpdateTaskRequest changes the given fields of a task. Estimates are
+// counted in the estimate unit of the task's project; an estimate of 0
+// clears it.
 type UpdateTaskRequest struct {
-	Title       string     `json:"title"`
-	Description string     `json:"description"`
-	Status      TaskStatus `json:"status"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Status      TaskStatus   `json:"status"`
+	DueDate     *string      `json:"due_date"`
+	DueTime     *string      `json:"due_time"`
+	DueTimezone *string      `json:"due_timezone"`
+	Priority    TaskPriority `json:"priority"`
+	Estimate    *float64     `json:"estimate" binding:"omitempty,min=0"`
+	ParentID    *string      `json:"parent_id"`
+	Recurrence  *string      `json:"recurrence"`
+	ProjectID   *string      `json:"project_id"`
+}
+
+type Occurrence struct {
+	DueDate string    `json:"due_date"`
+	DueTime string    `json:"due_time,omitempty"`
+	DueAt   time.Time `json:"due_at"`
+}
+
+type OccurrenceQuery struct {
+	Count int `form:"count" binding:"omitempty,min=1,max=100"`
+}
+
+type AssignTaskRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+}
+
+type AddDependencyRequest struct {
+	DependsOnID string `json:"depends_on_id" binding:"required"`
+}
+
+type ArchiveResult struct {
+	Archived int `json:"archived"`
+}
+
+type TransitionRequest struct {
+	Status TaskStatus `json:"status" binding:"required"`
+}
+
+// MoveTaskRequest places a task on the board. AfterID and BeforeID name the
+// tasks that end up directly above and below it in the target column; with
+// neither the task goes to the bottom of the column.
+type MoveTaskRequest struct {
+	Status   TaskStatus `json:"status"`
+	AfterID  string     `json:"after_id"`
+	BeforeID string     `json:"before_id"`
+}
+
+type TaskTransition struct {
+	ID         string     `json:"id"`
+	TaskID     string     `json:"task_id"`
+	FromStatus TaskStatus `json:"from_status"`
+	ToStatus   TaskStatus `json:"to_status"`
+	UserID     string     `json:"user_id"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// TaskSearchQuery searches task titles and descriptions. Q holds words that
+// must all match; "quoted phrases" match words next to each other and a
+// trailing * matches words starting with the given prefix.
+type TaskSearchQuery struct {
+	Q         string `form:"q" binding:"required"`
+	Limit     int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	ProjectID string `form:"project_id"`
+}
+
+// TaskSearchResult is a matching task with its relevance and a snippet of
+// its text in which the matches are wrapped in <mark> tags.
+type TaskSearchResult struct {
+	Task    Task    `json:"task"`
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
+
+// TaskQuery filters and pages a task listing. Pages are continued by passing
+// the previous page's next_cursor along with the same filters and sort.
+type TaskQuery struct {
+	Overdue         bool     `form:"overdue"`
+	DueBefore       string   `form:"due_before"`
+	DueAfter        string   `form:"due_after"`
+	CreatedBefore   string   `form:"created_before"`
+	CreatedAfter    string   `form:"created_after"`
+	CompletedBefore string   `form:"completed_before"`
+	CompletedAfter  string   `form:"completed_after"`
+	Done            *bool    `form:"done"`
+	Status          string   `form:"status"`
+	Owner           string   `form:"owner"`
+	Text            string   `form:"text"`
+	Sort            string   `form:"sort"`
+	Tags            []string `form:"tag"`
+	TagMode         string   `form:"tag_mode"`
+	ProjectID       string   `form:"project_id"`
+	Assignee        string   `form:"assignee"`
+	IncludeArchived bool     `form:"include_archived"`
+	Limit           int      `form:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor          string   `form:"cursor"`
+}
+
+type TaskPage struct {
+	Tasks      []Task `json:"tasks"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+func (p TaskPriority) IsValid() bool {
+	return p.Rank() >= 0
+}
+
+// Rank orders priorities from none (0) to urgent; it is what gets stored and sorted on.
+func (p TaskPriority) Rank() int {
+	for i, priority := range taskPriorities {
+		if priority == p {
+			return i
+		}
+	}
+	return -1
+}
+
+func PriorityFromRank(rank int) TaskPriority {
+	if rank < 0 || rank >= len(taskPriorities) {
+		return PriorityNone
+	}
+	return taskPriorities[rank]
+}
+
+// OnlyChangesStatus reports whether the request touches nothing but the status.
+func (r *UpdateTaskRequest) OnlyChangesStatus() bool {
+	return r.Title == "" && r.Description == "" && r.DueDate == nil && r.DueTime == nil &&
+		r.DueTimezone == nil && r.Priority == "" && r.ParentID == nil && r.Recurrence == nil &&
+		r.ProjectID == nil && r.Estimate == nil
+}
+
+func (t *Task) IsOverdue(now time.Time) bool {
+	return t.DueAt != nil && t.DueAt.Before(now) && !t.Done
+}
+
+// DueLocation resolves a task timezone, falling back to UTC.
+func DueLocation(timezone string) *time.Location {
+	if timezone != "" {
+		if loc, err := time.LoadLocation(timezone); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
 }
 
 func (t *Task) ConvertToRepositoryTask() repository.Task {
 	return repository.Task{
-		ID:          t.ID,
-		Title:       t.Title,
-		Description: t.Description,
-		Status:      string(t.Status),
-		UserID:      t.UserID,
+		ID:            t.ID,
+		Title:         t.Title,
+		Description:   t.Description,
+		StatusID:      t.StatusID,
+		Status:        string(t.Status),
+		Done:          t.Done,
+		BoardPosition: t.Position,
+		UserID:        t.UserID,
+		DueAt:         t.DueAt,
+		DueHasTime:    t.DueAt != nil && t.DueTime != "",
+		DueTimezone:   t.DueTimezone,
+		Recurrence:    t.Recurrence,
+		ProjectID:     t.ProjectID,
+		AssigneeID:    t.AssigneeID,
+		WorkspaceID:   t.WorkspaceID,
+		ArchivedAt:    t.ArchivedAt,
+		Priority:      t.Priority.Rank(),
+		Estimate:      t.Estimate,
+		CreatedAt:     t.CreatedAt,
+		CompletedAt:   t.CompletedAt,
+		ParentID:      t.ParentID,
 	}
 }
 
 func ConvertFromRepositoryTask(rt repository.Task) Task {
-	return Task{
+	task := Task{
 		ID:          rt.ID,
 		Title:       rt.Title,
 		Description: rt.Description,
 		Status:      TaskStatus(rt.Status),
+		StatusID:    rt.StatusID,
+		Done:        rt.Done,
+		Position:    rt.BoardPosition,
 		UserID:      rt.UserID,
+		DueTimezone: rt.DueTimezone,
+		DueAt:       rt.DueAt,
+		Recurrence:  rt.Recurrence,
+		ProjectID:   rt.ProjectID,
+		AssigneeID:  rt.AssigneeID,
+		WorkspaceID: rt.WorkspaceID,
+		Archived:    rt.ArchivedAt != nil,
+		ArchivedAt:  rt.ArchivedAt,
+		DeletedAt:   rt.DeletedAt,
+		Priority:    PriorityFromRank(rt.Priority),
+		Estimate:    rt.Estimate,
+		CreatedAt:   rt.CreatedAt,
+		CompletedAt: rt.CompletedAt,
+		Tags:        rt.Tags,
+		ParentID:    rt.ParentID,
+		BlockedBy:   rt.BlockedBy,
+		Blocks:      rt.Blocks,
+	}
+
+	if task.Tags == nil {
+		task.Tags = []string{}
+	}
+
+	if task.BlockedBy == nil {
+		task.BlockedBy = []string{}
+	}
+
+	if task.Blocks == nil {
+		task.Blocks = []string{}
+	}
+
+	if rt.DueAt != nil {
+		local := rt.DueAt.In(DueLocation(rt.DueTimezone))
+		task.DueDate = local.Format(DueDateLayout)
+		if rt.DueHasTime {
+			task.DueTime = local.Format(DueTimeLayout)
+		}
+	}
+
+	task.Overdue = task.IsOverdue(time.Now())
+
+	return task
+}
+
+func (t *TaskTransition) ConvertToRepositoryTransition() repository.TaskTransition {
+	return repository.TaskTransition{
+		ID:         t.ID,
+		TaskID:     t.TaskID,
+		FromStatus: string(t.FromStatus),
+		ToStatus:   string(t.ToStatus),
+		UserID:     t.UserID,
+		CreatedAt:  t.CreatedAt,
+	}
+}
+
+func ConvertFromRepositoryTransition(rt repository.TaskTransition) TaskTransition {
+	return TaskTransition{
+		ID:         rt.ID,
+		TaskID:     rt.TaskID,
+		FromStatus: TaskStatus(rt.FromStatus),
+		ToStatus:   TaskStatus(rt.ToStatus),
+		UserID:     rt.UserID,
+		CreatedAt:  rt.CreatedAt,
 	}
 }
@@ -0,0 +1,116 @@
+package models
+
+import (
+	"time"
+	"todo-api/internal/repository"
+)
+
+// Template describes a task, and optionally its subtasks, to be created
+// repeatedly. Titles and descriptions may contain placeholders such as
+// {{name}} that are filled in when the template is instantiated. A shared
+// template is visible to the whole workspace but only its owner can change it.
+type Template struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	UserID      string       `json:"user_id"`
+	WorkspaceID string       `json:"workspace_id"`
+	Shared      bool         `json:"shared"`
+	Task        TemplateTask `json:"task"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// TemplateTask is a task created from a template. DueInDays sets the due date
+// relative to the start date of the instantiation, and Items become its
+// subtasks.
+type TemplateTask struct {
+	Title       string         `json:"title" binding:"required"`
+	Description string         `json:"description,omitempty"`
+	Priority    TaskPriority   `json:"priority,omitempty"`
+	Estimate    *float64       `json:"estimate,omitempty" binding:"omitempty,min=0"`
+	DueInDays   *int           `json:"due_in_days,omitempty"`
+	DueTime     string         `json:"due_time,omitempty"`
+	Items       []TemplateTask `json:"items,omitempty" binding:"omitempty,dive"`
+}
+
+type CreateTemplateRequest struct {
+	Name   string       `json:"name" binding:"required,max=255"`
+	Shared bool         `json:"shared"`
+	Task   TemplateTask `json:"task" binding:"required"`
+}
+
+type UpdateTemplateRequest struct {
+	Name   string        `json:"name" binding:"max=255"`
+	Shared *bool         `json:"shared"`
+	Task   *TemplateTask `json:"task"`
+}
+
+// InstantiateTemplateRequest fills in the placeholders of a template with
+// Values. Due dates count from StartDate, today in UTC by default. The tasks
+// are created in ProjectID and assigned to AssigneeID when those are given.
+type InstantiateTemplateRequest struct {
+	Values     map[string]string `json:"values"`
+	StartDate  string            `json:"start_date"`
+	ProjectID  string            `json:"project_id"`
+	AssigneeID string            `json:"assignee_id"`
+}
+
+func (t *Template) ConvertToRepositoryTemplate() repository.Template {
+	return repository.Template{
+		ID:          t.ID,
+		UserID:      t.UserID,
+		WorkspaceID: t.WorkspaceID,
+		Name:        t.Name,
+		Shared:      t.Shared,
+		Task:        t.Task.ConvertToRepositoryTemplateTask(),
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
+
+func ConvertFromRepositoryTemplate(rt repository.Template) Template {
+	return Template{
+		ID:          rt.ID,
+		Name:        rt.Name,
+		UserID:      rt.UserID,
+		WorkspaceID: rt.WorkspaceID,
+		Shared:      rt.Shared,
+		Task:        ConvertFromRepositoryTemplateTask(rt.Task),
+		CreatedAt:   rt.CreatedAt,
+		UpdatedAt:   rt.UpdatedAt,
+	}
+}
+
+func (t *TemplateTask) ConvertToRepositoryTemplateTask() repository.TemplateTask {
+	task := repository.TemplateTask{
+		Title:       t.Title,
+		Description: t.Description,
+		Priority:    string(t.Priority),
+		Estimate:    t.Estimate,
+		DueInDays:   t.DueInDays,
+		DueTime:     t.DueTime,
+	}
+
+	for _, item := range t.Items {
+		task.Items = append(task.Items, item.ConvertToRepositoryTemplateTask())
+	}
+
+	return task
+}
+
+func ConvertFromRepositoryTemplateTask(rt repository.TemplateTask) TemplateTask {
+	task := TemplateTask{
+		Title:       rt.Title,
+		Description: rt.Description,
+		Priority:    TaskPriority(rt.Priority),
+		Estimate:    rt.Estimate,
+		DueInDays:   rt.DueInDays,
+		DueTime:     rt.DueTime,
+	}
+
+	for _, item := range rt.Items {
+		task.Items = append(task.Items, ConvertFromRepositoryTemplateTask(item))
+	}
+
+	return task
+}
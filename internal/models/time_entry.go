@@ -0,0 +1,108 @@
+package models
+
+import (
+	"time"
+	"todo-api/internal/repository"
+)
+
+const (
+	TimeGroupByTask    = "task"
+	TimeGroupByUser    = "user"
+	TimeGroupByProject = "project"
+)
+
+// TimeEntry is a span of time a user spent on a task. Duration is given in
+// seconds; for a running timer it counts up to now.
+type TimeEntry struct {
+	ID        string     `json:"id"`
+	TaskID    string     `json:"task_id"`
+	UserID    string     `json:"user_id"`
+	StartedAt time.Time  `json:"started_at"`
+	StoppedAt *time.Time `json:"stopped_at"`
+	Duration  int64      `json:"duration"`
+	Running   bool       `json:"running"`
+	Note      string     `json:"note"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type StartTimerRequest struct {
+	Note string `json:"note"`
+}
+
+// TimeEntryRequest logs time by hand, either with an explicit stop time or
+// with a duration in seconds counted from started_at.
+type TimeEntryRequest struct {
+	StartedAt time.Time  `json:"started_at" binding:"required"`
+	StoppedAt *time.Time `json:"stopped_at"`
+	Duration  int64      `json:"duration" binding:"omitempty,min=1"`
+	Note      string     `json:"note"`
+}
+
+type UpdateTimeEntryRequest struct {
+	StartedAt *time.Time `json:"started_at"`
+	StoppedAt *time.Time `json:"stopped_at"`
+	Note      *string    `json:"note"`
+}
+
+// TimeTotalsQuery selects the entries to sum up. From is inclusive and To is
+// exclusive; both accept a date or an RFC 3339 timestamp.
+type TimeTotalsQuery struct {
+	GroupBy   string `form:"group_by"`
+	From      string `form:"from"`
+	To        string `form:"to"`
+	ProjectID string `form:"project_id"`
+	TaskID    string `form:"task_id"`
+	UserID    string `form:"user_id"`
+}
+
+type TimeTotals struct {
+	GroupBy string      `json:"group_by"`
+	Total   int64       `json:"total"`
+	Groups  []TimeTotal `json:"groups"`
+}
+
+type TimeTotal struct {
+	ID      string `json:"id"`
+	Seconds int64  `json:"seconds"`
+}
+
+func (e *TimeEntry) ConvertToRepositoryTimeEntry() repository.TimeEntry {
+	return repository.TimeEntry{
+		ID:        e.ID,
+		TaskID:    e.TaskID,
+		UserID:    e.UserID,
+		StartedAt: e.StartedAt,
+		StoppedAt: e.StoppedAt,
+		Note:      e.Note,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+func ConvertFromRepositoryTimeEntry(re repository.TimeEntry) TimeEntry {
+	return TimeEntry{
+		ID:        re.ID,
+		TaskID:    re.TaskID,
+		UserID:    re.UserID,
+		StartedAt: re.StartedAt,
+		StoppedAt: re.StoppedAt,
+		Duration:  int64(TimeEntryDuration(re, time.Now()) / time.Second),
+		Running:   re.StoppedAt == nil,
+		Note:      re.Note,
+		CreatedAt: re.CreatedAt,
+	}
+}
+
+// TimeEntryDuration is how long an entry lasted, or has lasted by now when
+// its timer is still running.
+func TimeEntryDuration(re repository.TimeEntry, now time.Time) time.Duration {
+	end := now
+	if re.StoppedAt != nil {
+		end = *re.StoppedAt
+	}
+
+	if end.Before(re.StartedAt) {
+		return 0
+	}
+
+	return end.Sub(re.StartedAt)
+}
@@ -3,10 +3,11 @@ package models
 import "todo-api/internal/repository"
 
 type User struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	Email    string `json:"email"`
-	Password string `json:"-"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	Password    string `json:"-"`
+	WorkspaceID string `json:"workspace_id"`
 }
 
 type LoginRequest struct {
@@ -34,18 +35,20 @@ type UserResponse struct {
 
 func (u *User) ConvertToRepositoryUser() repository.User {
 	return repository.User{
-		ID:       u.ID,
-		Name:     u.Name,
-		Email:    u.Email,
-		Password: u.Password,
+		ID:          u.ID,
+		Name:        u.Name,
+		Email:       u.Email,
+		Password:    u.Password,
+		WorkspaceID: u.WorkspaceID,
 	}
 }
 
 func ConvertFromRepositoryUser(ru repository.User) User {
 	return User{
-		ID:       ru.ID,
-		Name:     ru.Name,
-		Email:    ru.Email,
-		Password: ru.Password,
+		ID:          ru.ID,
+		Name:        ru.Name,
+		Email:       ru.Email,
+		Password:    ru.Password,
+		WorkspaceID: ru.WorkspaceID,
 	}
 }
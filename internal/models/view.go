@@ -0,0 +1,117 @@
+package models
+
+import (
+	"time"
+	"todo-api/internal/repository"
+)
+
+// View is a named task listing. System views are built in and evaluated
+// relative to the current time; the others are saved by a user and may be
+// shared with other members of the workspace.
+type View struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	UserID      string     `json:"user_id,omitempty"`
+	WorkspaceID string     `json:"workspace_id,omitempty"`
+	System      bool       `json:"system"`
+	Filter      ViewFilter `json:"filter"`
+	SharedWith  []string   `json:"shared_with"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+// ViewFilter holds the filters and sort of a task listing, with the same
+// meaning as the parameters of GET /tasks.
+type ViewFilter struct {
+	Overdue         bool     `json:"overdue,omitempty"`
+	DueBefore       string   `json:"due_before,omitempty"`
+	DueAfter        string   `json:"due_after,omitempty"`
+	CreatedBefore   string   `json:"created_before,omitempty"`
+	CreatedAfter    string   `json:"created_after,omitempty"`
+	CompletedBefore string   `json:"completed_before,omitempty"`
+	CompletedAfter  string   `json:"completed_after,omitempty"`
+	Done            *bool    `json:"done,omitempty"`
+	Status          string   `json:"status,omitempty"`
+	Owner           string   `json:"owner,omitempty"`
+	Text            string   `json:"text,omitempty"`
+	Sort            string   `json:"sort,omitempty"`
+	Tags            []string `json:"tags,omitempty"`
+	TagMode         string   `json:"tag_mode,omitempty"`
+	ProjectID       string   `json:"project_id,omitempty"`
+	Assignee        string   `json:"assignee,omitempty"`
+	IncludeArchived bool     `json:"include_archived,omitempty"`
+}
+
+type CreateViewRequest struct {
+	Name       string     `json:"name" binding:"required,max=255"`
+	Filter     ViewFilter `json:"filter"`
+	SharedWith []string   `json:"shared_with"`
+}
+
+// UpdateViewRequest changes the given parts of a view. SharedWith replaces
+// the users the view is shared with; an empty list stops sharing it.
+type UpdateViewRequest struct {
+	Name       string      `json:"name" binding:"max=255"`
+	Filter     *ViewFilter `json:"filter"`
+	SharedWith []string    `json:"shared_with"`
+}
+
+type ViewTasksQuery struct {
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor string `form:"cursor"`
+}
+
+func (f ViewFilter) TaskQuery() TaskQuery {
+	return TaskQuery{
+		Overdue:         f.Overdue,
+		DueBefore:       f.DueBefore,
+		DueAfter:        f.DueAfter,
+		CreatedBefore:   f.CreatedBefore,
+		CreatedAfter:    f.CreatedAfter,
+		CompletedBefore: f.CompletedBefore,
+		CompletedAfter:  f.CompletedAfter,
+		Done:            f.Done,
+		Status:          f.Status,
+		Owner:           f.Owner,
+		Text:            f.Text,
+		Sort:            f.Sort,
+		Tags:            f.Tags,
+		TagMode:         f.TagMode,
+		ProjectID:       f.ProjectID,
+		Assignee:        f.Assignee,
+		IncludeArchived: f.IncludeArchived,
+	}
+}
+
+func (v *View) ConvertToRepositoryView() repository.View {
+	view := repository.View{
+		ID:          v.ID,
+		UserID:      v.UserID,
+		WorkspaceID: v.WorkspaceID,
+		Name:        v.Name,
+		Filter:      repository.ViewFilter(v.Filter),
+	}
+
+	if v.CreatedAt != nil {
+		view.CreatedAt = *v.CreatedAt
+	}
+
+	if v.UpdatedAt != nil {
+		view.UpdatedAt = *v.UpdatedAt
+	}
+
+	return view
+}
+
+func ConvertFromRepositoryView(rv repository.View) View {
+	return View{
+		ID:          rv.ID,
+		Name:        rv.Name,
+		UserID:      rv.UserID,
+		WorkspaceID: rv.WorkspaceID,
+		Filter:      ViewFilter(rv.Filter),
+		SharedWith:  []string{},
+		CreatedAt:   &rv.CreatedAt,
+		UpdatedAt:   &rv.UpdatedAt,
+	}
+}
@@ -0,0 +1,64 @@
+package models
+
+import "todo-api/internal/repository"
+
+// Workflow is the ordered set of statuses the tasks of a project move
+// through. Projects without a custom workflow use the default one.
+type Workflow struct {
+	ProjectID   string               `json:"project_id,omitempty"`
+	Custom      bool                 `json:"custom"`
+	Statuses    []WorkflowStatus     `json:"statuses"`
+	Transitions []WorkflowTransition `json:"transitions"`
+}
+
+type WorkflowStatus struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Position int    `json:"position"`
+	Done     bool   `json:"done"`
+	WIPLimit int    `json:"wip_limit"`
+}
+
+// WorkflowTransition allows tasks to move from one status to another, both
+// given by name. A workflow without transitions allows every move.
+type WorkflowTransition struct {
+	From TaskStatus `json:"from" binding:"required"`
+	To   TaskStatus `json:"to" binding:"required"`
+}
+
+type WorkflowStatusRequest struct {
+	Name     string `json:"name" binding:"required,max=50"`
+	Done     bool   `json:"done"`
+	WIPLimit int    `json:"wip_limit" binding:"min=0"`
+}
+
+type UpdateWorkflowRequest struct {
+	Statuses    []WorkflowStatusRequest `json:"statuses" binding:"required,dive"`
+	Transitions []WorkflowTransition    `json:"transitions" binding:"dive"`
+}
+
+func ConvertFromRepositoryWorkflowStatus(rs repository.WorkflowStatus) WorkflowStatus {
+	return WorkflowStatus{
+		ID:       rs.ID,
+		Name:     rs.Name,
+		Position: rs.Position,
+		Done:     rs.Done,
+		WIPLimit: rs.WIPLimit,
+	}
+}
+
+// Board lays out the tasks of a project, or of the Inbox, in one column per
+// workflow status.
+type Board struct {
+	ProjectID string        `json:"project_id,omitempty"`
+	Columns   []BoardColumn `json:"columns"`
+}
+
+type BoardColumn struct {
+	WorkflowStatus
+	Tasks []Task `json:"tasks"`
+}
+
+type BoardQuery struct {
+	ProjectID string `form:"project_id"`
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+	"todo-api/internal/repository"
+)
+
+const (
+	WorkspaceRoleOwner  = "owner"
+	WorkspaceRoleMember = "member"
+)
+
+type Workspace struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	OwnerID   string    `json:"owner_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type UserWorkspace struct {
+	Workspace
+	Role   string `json:"role"`
+	Active bool   `json:"active"`
+}
+
+type WorkspaceMember struct {
+	UserID    string    `json:"user_id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateWorkspaceRequest struct {
+	Name string `json:"name" binding:"required,max=255"`
+}
+
+// WorkspaceInvitation is a pending membership; the invited user becomes a
+// member only once they accept it.
+type WorkspaceInvitation struct {
+	ID            string    `json:"id"`
+	WorkspaceID   string    `json:"workspace_id"`
+	WorkspaceName string    `json:"workspace_name"`
+	UserID        string    `json:"user_id"`
+	Email         string    `json:"email"`
+	InvitedBy     string    `json:"invited_by"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type InviteWorkspaceMemberRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+func (w *Workspace) ConvertToRepositoryWorkspace() repository.Workspace {
+	return repository.Workspace{
+		ID:        w.ID,
+		Name:      w.Name,
+		OwnerID:   w.OwnerID,
+		CreatedAt: w.CreatedAt,
+	}
+}
+
+func ConvertFromRepositoryWorkspace(rw repository.Workspace) Workspace {
+	return Workspace{
+		ID:        rw.ID,
+		Name:      rw.Name,
+		OwnerID:   rw.OwnerID,
+		CreatedAt: rw.CreatedAt,
+	}
+}
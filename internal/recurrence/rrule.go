@@ -0,0 +1,369 @@
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rule is the supported subset of an RFC 5545 RRULE: FREQ (DAILY, WEEKLY,
+// MONTHLY, YEARLY), INTERVAL, BYDAY, COUNT and UNTIL. COUNT is the number of
+// occurrences left in the series, including the current one.
+type Rule struct {
+	Freq     string
+	Interval int
+	ByDay    []WeekdayNum
+	Count    int
+	Until    *time.Time
+}
+
+// WeekdayNum is a BYDAY entry such as MO, 2TU or -1FR. N is only meaningful
+// for MONTHLY rules, where zero means every such weekday of the month.
+type WeekdayNum struct {
+	N       int
+	Weekday time.Weekday
+}
+
+const (
+	Daily   = "DAILY"
+	Weekly  = "WEEKLY"
+	Monthly = "MONTHLY"
+	Yearly  = "YEARLY"
+)
+
+const untilLayout = "20060102T150405Z"
+
+// maxIterations bounds the search for the next occurrence of rules that match
+// rarely or never, such as FREQ=MONTHLY;BYDAY=5MO with a large INTERVAL.
+const maxIterations = 1000
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+func Parse(value string) (*Rule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return nil, errors.New("empty recurrence rule")
+	}
+
+	rule := Rule{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("malformed rule part %q", part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			switch freq := strings.ToUpper(val); freq {
+			case Daily, Weekly, Monthly, Yearly:
+				rule.Freq = freq
+			default:
+				return nil, fmt.Errorf("unsupported FREQ %q", val)
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 {
+				return nil, fmt.Errorf("invalid INTERVAL %q", val)
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("invalid COUNT %q", val)
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := parseUntil(val)
+			if err != nil {
+				return nil, fmt.Errorf("invalid UNTIL %q", val)
+			}
+			rule.Until = &until
+		case "BYDAY":
+			for _, day := range strings.Split(val, ",") {
+				weekdayNum, err := parseWeekdayNum(day)
+				if err != nil {
+					return nil, err
+				}
+				rule.ByDay = append(rule.ByDay, weekdayNum)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported rule part %q", key)
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, errors.New("FREQ is required")
+	}
+
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, errors.New("COUNT and UNTIL cannot be combined")
+	}
+
+	for _, day := range rule.ByDay {
+		if day.N != 0 && rule.Freq != Monthly {
+			return nil, errors.New("BYDAY ordinals are only supported with FREQ=MONTHLY")
+		}
+	}
+
+	if len(rule.ByDay) > 0 && rule.Freq == Yearly {
+		return nil, errors.New("BYDAY is not supported with FREQ=YEARLY")
+	}
+
+	return &rule, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	if t, err := time.Parse(untilLayout, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse("20060102", value)
+	if err != nil {
+		return t, err
+	}
+
+	// A date-only UNTIL includes that whole day.
+	return t.Add(24*time.Hour - time.Second), nil
+}
+
+func parseWeekdayNum(value string) (WeekdayNum, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if len(value) < 2 {
+		return WeekdayNum{}, fmt.Errorf("invalid BYDAY %q", value)
+	}
+
+	weekday, ok := weekdays[value[len(value)-2:]]
+	if !ok {
+		return WeekdayNum{}, fmt.Errorf("invalid BYDAY %q", value)
+	}
+
+	n := 0
+	if prefix := value[:len(value)-2]; prefix != "" {
+		var err error
+		n, err = strconv.Atoi(prefix)
+		if err != nil || n == 0 || n < -5 || n > 5 {
+			return WeekdayNum{}, fmt.Errorf("invalid BYDAY %q", value)
+		}
+	}
+
+	return WeekdayNum{N: n, Weekday: weekday}, nil
+}
+
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = day.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilLayout))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+func (d WeekdayNum) String() string {
+	name := strings.ToUpper(d.Weekday.String()[:2])
+	if d.N == 0 {
+		return name
+	}
+	return strconv.Itoa(d.N) + name
+}
+
+// Next returns the occurrence following prev, keeping its wall clock time in
+// prev's location. The second result is false once the series has ended.
+func (r *Rule) Next(prev time.Time) (time.Time, bool) {
+	if r.Count == 1 {
+		return time.Time{}, false
+	}
+
+	var next time.Time
+	var ok bool
+	switch r.Freq {
+	case Daily:
+		next, ok = r.nextDaily(prev)
+	case Weekly:
+		next, ok = r.nextWeekly(prev)
+	case Monthly:
+		next, ok = r.nextMonthly(prev)
+	case Yearly:
+		next, ok = r.nextYearly(prev)
+	}
+
+	if !ok || (r.Until != nil && next.After(*r.Until)) {
+		return time.Time{}, false
+	}
+
+	return next, true
+}
+
+// Advance returns the rule that governs the series from the next occurrence on.
+func (r *Rule) Advance() *Rule {
+	next := *r
+	if next.Count > 0 {
+		next.Count--
+	}
+	return &next
+}
+
+// Occurrences lists up to n occurrences starting with start itself.
+func (r *Rule) Occurrences(start time.Time, n int) []time.Time {
+	var occurrences []time.Time
+	rule, current := r, start
+	for len(occurrences) < n {
+		occurrences = append(occurrences, current)
+
+		next, ok := rule.Next(current)
+		if !ok {
+			break
+		}
+		rule, current = rule.Advance(), next
+	}
+
+	return occurrences
+}
+
+func (r *Rule) nextDaily(prev time.Time) (time.Time, bool) {
+	for i := 1; i <= maxIterations; i++ {
+		candidate := prev.AddDate(0, 0, i*r.Interval)
+		if r.matchesWeekday(candidate.Weekday()) {
+			return candidate, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (r *Rule) nextWeekly(prev time.Time) (time.Time, bool) {
+	if len(r.ByDay) == 0 {
+		return prev.AddDate(0, 0, 7*r.Interval), true
+	}
+
+	weekStart := prev.AddDate(0, 0, -daysSinceMonday(prev.Weekday()))
+	for i := 1; i <= maxIterations; i++ {
+		candidate := prev.AddDate(0, 0, i)
+		weeks := int(dateOnly(candidate).Sub(dateOnly(weekStart)).Hours()/24) / 7
+		if weeks%r.Interval == 0 && r.matchesWeekday(candidate.Weekday()) {
+			return candidate, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (r *Rule) nextMonthly(prev time.Time) (time.Time, bool) {
+	if len(r.ByDay) == 0 {
+		for i := 1; i <= maxIterations; i++ {
+			if candidate := addMonthsExact(prev, i*r.Interval); candidate != nil {
+				return *candidate, true
+			}
+		}
+		return time.Time{}, false
+	}
+
+	year, month, _ := prev.Date()
+	for i := 0; i <= maxIterations; i++ {
+		first := time.Date(year, month+time.Month(i*r.Interval), 1,
+			prev.Hour(), prev.Minute(), prev.Second(), prev.Nanosecond(), prev.Location())
+		for _, day := range r.monthDays(first) {
+			if day.After(prev) {
+				return day, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func (r *Rule) nextYearly(prev time.Time) (time.Time, bool) {
+	for i := 1; i <= maxIterations; i++ {
+		candidate := addMonthsExact(prev, 12*i*r.Interval)
+		if candidate != nil {
+			return *candidate, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// monthDays lists the days of first's month selected by BYDAY, in order.
+func (r *Rule) monthDays(first time.Time) []time.Time {
+	var days []time.Time
+	for _, byDay := range r.ByDay {
+		var matching []time.Time
+		for d := first; d.Month() == first.Month(); d = d.AddDate(0, 0, 1) {
+			if d.Weekday() == byDay.Weekday {
+				matching = append(matching, d)
+			}
+		}
+
+		switch {
+		case byDay.N == 0:
+			days = append(days, matching...)
+		case byDay.N > 0 && byDay.N <= len(matching):
+			days = append(days, matching[byDay.N-1])
+		case byDay.N < 0 && -byDay.N <= len(matching):
+			days = append(days, matching[len(matching)+byDay.N])
+		}
+	}
+
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Before(days[j])
+	})
+
+	return days
+}
+
+func (r *Rule) matchesWeekday(weekday time.Weekday) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+
+	for _, day := range r.ByDay {
+		if day.Weekday == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// addMonthsExact adds months keeping the day of month, returning nil when the
+// target month has no such day (RFC 5545 skips those instead of rolling over).
+func addMonthsExact(t time.Time, months int) *time.Time {
+	year, month, day := t.Date()
+	candidate := time.Date(year, month+time.Month(months), day,
+		t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if candidate.Day() != day {
+		return nil
+	}
+	return &candidate
+}
+
+func daysSinceMonday(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
+
+func dateOnly(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
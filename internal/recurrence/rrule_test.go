@@ -0,0 +1,221 @@
+package recurrence
+
+import (
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"daily", "FREQ=DAILY", "FREQ=DAILY"},
+		{"prefix and case", "RRULE:freq=monthly;byday=-1fr;interval=2", "FREQ=MONTHLY;INTERVAL=2;BYDAY=-1FR"},
+		{"weekly days", "FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=5", "FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=5"},
+		{"until with time", "FREQ=DAILY;UNTIL=20260105T120000Z", "FREQ=DAILY;UNTIL=20260105T120000Z"},
+		{"date-only until", "FREQ=DAILY;UNTIL=20260105", "FREQ=DAILY;UNTIL=20260105T235959Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.value)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.value, err)
+			}
+			if got := rule.String(); got != tt.want {
+				t.Errorf("Parse(%q).String() = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"empty", ""},
+		{"missing freq", "INTERVAL=2"},
+		{"unsupported freq", "FREQ=HOURLY"},
+		{"malformed part", "FREQ=DAILY;COUNT"},
+		{"unknown part", "FREQ=DAILY;BYHOUR=9"},
+		{"zero interval", "FREQ=DAILY;INTERVAL=0"},
+		{"zero count", "FREQ=DAILY;COUNT=0"},
+		{"invalid until", "FREQ=DAILY;UNTIL=tomorrow"},
+		{"count and until", "FREQ=DAILY;COUNT=2;UNTIL=20260101"},
+		{"unknown weekday", "FREQ=WEEKLY;BYDAY=XX"},
+		{"ordinal out of range", "FREQ=MONTHLY;BYDAY=6MO"},
+		{"ordinal outside monthly", "FREQ=WEEKLY;BYDAY=1MO"},
+		{"byday with yearly", "FREQ=YEARLY;BYDAY=MO"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.value); err == nil {
+				t.Errorf("Parse(%q) succeeded, want an error", tt.value)
+			}
+		})
+	}
+}
+
+func TestOccurrences(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		n     int
+		want  []time.Time
+	}{
+		{
+			name:  "daily interval",
+			rule:  "FREQ=DAILY;INTERVAL=2",
+			start: date(2026, time.January, 1),
+			n:     3,
+			want:  []time.Time{date(2026, time.January, 1), date(2026, time.January, 3), date(2026, time.January, 5)},
+		},
+		{
+			name:  "daily on weekdays",
+			rule:  "FREQ=DAILY;BYDAY=MO,WE,FR",
+			start: date(2026, time.January, 1),
+			n:     4,
+			want:  []time.Time{date(2026, time.January, 1), date(2026, time.January, 2), date(2026, time.January, 5), date(2026, time.January, 7)},
+		},
+		{
+			name:  "weekly",
+			rule:  "FREQ=WEEKLY",
+			start: date(2026, time.January, 1),
+			n:     3,
+			want:  []time.Time{date(2026, time.January, 1), date(2026, time.January, 8), date(2026, time.January, 15)},
+		},
+		{
+			name:  "every other week on tuesday and thursday",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH",
+			start: date(2026, time.January, 6),
+			n:     4,
+			want:  []time.Time{date(2026, time.January, 6), date(2026, time.January, 8), date(2026, time.January, 20), date(2026, time.January, 22)},
+		},
+		{
+			name:  "monthly skips short months",
+			rule:  "FREQ=MONTHLY",
+			start: date(2026, time.January, 31),
+			n:     3,
+			want:  []time.Time{date(2026, time.January, 31), date(2026, time.March, 31), date(2026, time.May, 31)},
+		},
+		{
+			name:  "monthly on the second tuesday",
+			rule:  "FREQ=MONTHLY;BYDAY=2TU",
+			start: date(2026, time.January, 1),
+			n:     3,
+			want:  []time.Time{date(2026, time.January, 1), date(2026, time.January, 13), date(2026, time.February, 10)},
+		},
+		{
+			name:  "monthly on the last friday",
+			rule:  "FREQ=MONTHLY;BYDAY=-1FR",
+			start: date(2026, time.January, 30),
+			n:     3,
+			want:  []time.Time{date(2026, time.January, 30), date(2026, time.February, 27), date(2026, time.March, 27)},
+		},
+		{
+			name:  "yearly on a leap day",
+			rule:  "FREQ=YEARLY",
+			start: date(2024, time.February, 29),
+			n:     2,
+			want:  []time.Time{date(2024, time.February, 29), date(2028, time.February, 29)},
+		},
+		{
+			name:  "count ends the series",
+			rule:  "FREQ=DAILY;COUNT=3",
+			start: date(2026, time.January, 1),
+			n:     10,
+			want:  []time.Time{date(2026, time.January, 1), date(2026, time.January, 2), date(2026, time.January, 3)},
+		},
+		{
+			name:  "date-only until includes its day",
+			rule:  "FREQ=DAILY;UNTIL=20260103",
+			start: date(2026, time.January, 1),
+			n:     10,
+			want:  []time.Time{date(2026, time.January, 1), date(2026, time.January, 2), date(2026, time.January, 3)},
+		},
+		{
+			name:  "until before the next occurrence",
+			rule:  "FREQ=DAILY;UNTIL=20260102T080000Z",
+			start: date(2026, time.January, 1),
+			n:     10,
+			want:  []time.Time{date(2026, time.January, 1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.rule, err)
+			}
+
+			got := rule.Occurrences(tt.start, tt.n)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Occurrences() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("Occurrences()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   string
+		prev   time.Time
+		want   time.Time
+		wantOK bool
+	}{
+		{"daily", "FREQ=DAILY", date(2026, time.December, 31), date(2027, time.January, 1), true},
+		{"weekly on monday from a sunday", "FREQ=WEEKLY;BYDAY=MO", date(2026, time.January, 4), date(2026, time.January, 5), true},
+		{"monthly on every monday", "FREQ=MONTHLY;BYDAY=MO", date(2026, time.January, 26), date(2026, time.February, 2), true},
+		{"last occurrence by count", "FREQ=DAILY;COUNT=1", date(2026, time.January, 1), time.Time{}, false},
+		{"past until", "FREQ=WEEKLY;UNTIL=20260105", date(2026, time.January, 1), time.Time{}, false},
+		{"fifth monday every year", "FREQ=MONTHLY;INTERVAL=12;BYDAY=5MO", date(2026, time.March, 30), date(2027, time.March, 29), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.rule, err)
+			}
+
+			got, ok := rule.Next(tt.prev)
+			if ok != tt.wantOK {
+				t.Fatalf("Next(%v) ok = %v, want %v", tt.prev, ok, tt.wantOK)
+			}
+			if ok && !got.Equal(tt.want) {
+				t.Errorf("Next(%v) = %v, want %v", tt.prev, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdvance(t *testing.T) {
+	rule, err := Parse("FREQ=DAILY;COUNT=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	next := rule.Advance()
+	if next.Count != 1 || rule.Count != 2 {
+		t.Errorf("Advance() count = %d (original %d), want 1 (original 2)", next.Count, rule.Count)
+	}
+	if _, ok := next.Next(date(2026, time.January, 2)); ok {
+		t.Error("Next() after the last counted occurrence should end the series")
+	}
+}
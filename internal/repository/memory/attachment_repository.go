@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"maps"
+	"sort"
+	"todo-api/internal/repository"
+)
+
+type attachmentRepository struct {
+	attachments map[string]repository.Attachment
+}
+
+func NewAttachmentRepository() repository.AttachmentRepository {
+	return &attachmentRepository{
+		attachments: make(map[string]repository.Attachment),
+	}
+}
+
+func (r *attachmentRepository) Create(attachment repository.Attachment) error {
+	r.attachments[attachment.ID] = attachment
+	return nil
+}
+
+func (r *attachmentRepository) GetByID(id string) (*repository.Attachment, error) {
+	attachment, exists := r.attachments[id]
+	if !exists {
+		return nil, nil
+	}
+
+	return &attachment, nil
+}
+
+func (r *attachmentRepository) GetByTaskID(taskID string) ([]repository.Attachment, error) {
+	var taskAttachments []repository.Attachment
+	for _, attachment := range r.attachments {
+		if attachment.TaskID == taskID {
+			taskAttachments = append(taskAttachments, attachment)
+		}
+	}
+
+	sort.Slice(taskAttachments, func(i, j int) bool {
+		return taskAttachments[i].CreatedAt.Before(taskAttachments[j].CreatedAt)
+	})
+
+	return taskAttachments, nil
+}
+
+func (r *attachmentRepository) CountByChecksum(checksum string) (int, error) {
+	count := 0
+	for _, attachment := range r.attachments {
+		if attachment.Checksum == checksum {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (r *attachmentRepository) Delete(id string) error {
+	delete(r.attachments, id)
+	return nil
+}
+
+func (r *attachmentRepository) snapshot() func() {
+	attachments := maps.Clone(r.attachments)
+
+	return func() {
+		r.attachments = attachments
+	}
+}
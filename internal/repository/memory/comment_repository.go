@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"maps"
+	"sort"
+	"todo-api/internal/repository"
+)
+
+type commentRepository struct {
+	comments map[string]repository.Comment
+}
+
+func NewCommentRepository() repository.CommentRepository {
+	return &commentRepository{
+		comments: make(map[string]repository.Comment),
+	}
+}
+
+func (r *commentRepository) Create(comment repository.Comment) error {
+	r.comments[comment.ID] = comment
+	return nil
+}
+
+func (r *commentRepository) GetByID(id string) (*repository.Comment, error) {
+	comment, exists := r.comments[id]
+	if !exists {
+		return nil, nil
+	}
+
+	return &comment, nil
+}
+
+func (r *commentRepository) GetByTaskID(taskID string, limit, offset int) ([]repository.Comment, error) {
+	taskComments := r.taskComments(taskID)
+
+	if offset >= len(taskComments) {
+		return nil, nil
+	}
+
+	end := offset + limit
+	if end > len(taskComments) {
+		end = len(taskComments)
+	}
+
+	return taskComments[offset:end], nil
+}
+
+func (r *commentRepository) CountByTaskID(taskID string) (int, error) {
+	return len(r.taskComments(taskID)), nil
+}
+
+func (r *commentRepository) Update(comment repository.Comment) error {
+	if _, exists := r.comments[comment.ID]; !exists {
+		return nil
+	}
+
+	r.comments[comment.ID] = comment
+	return nil
+}
+
+func (r *commentRepository) Delete(id string) error {
+	delete(r.comments, id)
+	return nil
+}
+
+func (r *commentRepository) taskComments(taskID string) []repository.Comment {
+	var taskComments []repository.Comment
+	for _, comment := range r.comments {
+		if comment.TaskID == taskID {
+			taskComments = append(taskComments, comment)
+		}
+	}
+
+	sort.Slice(taskComments, func(i, j int) bool {
+		if !taskComments[i].CreatedAt.Equal(taskComments[j].CreatedAt) {
+			return taskComments[i].CreatedAt.Before(taskComments[j].CreatedAt)
+		}
+		return taskComments[i].ID < taskComments[j].ID
+	})
+
+	return taskComments
+}
+
+func (r *commentRepository) snapshot() func() {
+	comments := maps.Clone(r.comments)
+
+	return func() {
+		r.comments = comments
+	}
+}
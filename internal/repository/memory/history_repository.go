@@ -0,0 +1,37 @@
+package memory
+
+import (
+	"todo-api/internal/repository"
+)
+
+type historyRepository struct {
+	entries map[string][]repository.TaskHistoryEntry
+}
+
+func NewHistoryRepository() repository.HistoryRepository {
+	return &historyRepository{
+		entries: make(map[string][]repository.TaskHistoryEntry),
+	}
+}
+
+func (r *historyRepository) Create(entry repository.TaskHistoryEntry) error {
+	r.entries[entry.TaskID] = append(r.entries[entry.TaskID], entry)
+	return nil
+}
+
+func (r *historyRepository) GetByTaskID(taskID string) ([]repository.TaskHistoryEntry, error) {
+	entries := make([]repository.TaskHistoryEntry, len(r.entries[taskID]))
+	copy(entries, r.entries[taskID])
+	return entries, nil
+}
+
+func (r *historyRepository) snapshot() func() {
+	entries := make(map[string][]repository.TaskHistoryEntry, len(r.entries))
+	for taskID, taskEntries := range r.entries {
+		entries[taskID] = taskEntries
+	}
+
+	return func() {
+		r.entries = entries
+	}
+}
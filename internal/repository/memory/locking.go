@@ -0,0 +1,629 @@
+package memory
+
+import (
+	"sync"
+	"time"
+	"todo-api/internal/repository"
+)
+
+// The guarded repositories take the lock of their store for every call, so
+// requests, transactions and the trash purger never touch the maps at once.
+// Transactions hold the lock throughout and work on the repositories
+// underneath.
+
+type guardedTaskRepository struct {
+	mu   *sync.Mutex
+	repo repository.TaskRepository
+}
+
+func (r *guardedTaskRepository) Create(task repository.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Create(task)
+}
+
+func (r *guardedTaskRepository) GetByID(id string) (*repository.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetByID(id)
+}
+
+func (r *guardedTaskRepository) List(filter repository.TaskFilter) ([]repository.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.List(filter)
+}
+
+func (r *guardedTaskRepository) GetByUserID(userID string) ([]repository.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetByUserID(userID)
+}
+
+func (r *guardedTaskRepository) GetChildren(parentID string) ([]repository.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetChildren(parentID)
+}
+
+func (r *guardedTaskRepository) Update(task repository.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Update(task)
+}
+
+func (r *guardedTaskRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Delete(id)
+}
+
+func (r *guardedTaskRepository) AddTag(taskID, tagID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.AddTag(taskID, tagID)
+}
+
+func (r *guardedTaskRepository) RemoveTag(taskID, tagID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.RemoveTag(taskID, tagID)
+}
+
+func (r *guardedTaskRepository) GetTags(taskID string) ([]repository.Tag, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetTags(taskID)
+}
+
+func (r *guardedTaskRepository) AddDependency(taskID, dependsOnID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.AddDependency(taskID, dependsOnID)
+}
+
+func (r *guardedTaskRepository) RemoveDependency(taskID, dependsOnID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.RemoveDependency(taskID, dependsOnID)
+}
+
+func (r *guardedTaskRepository) GetDependencies(taskID string) ([]repository.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetDependencies(taskID)
+}
+
+func (r *guardedTaskRepository) GetDependencyIDs(taskID string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetDependencyIDs(taskID)
+}
+
+func (r *guardedTaskRepository) MoveProjectTasks(fromProjectID, toProjectID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.MoveProjectTasks(fromProjectID, toProjectID)
+}
+
+func (r *guardedTaskRepository) DeleteByProjectID(projectID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.DeleteByProjectID(projectID)
+}
+
+func (r *guardedTaskRepository) ReplaceStatus(projectID, fromStatusID, toStatusID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.ReplaceStatus(projectID, fromStatusID, toStatusID)
+}
+
+func (r *guardedTaskRepository) SoftDelete(id string, deletedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.SoftDelete(id, deletedAt)
+}
+
+func (r *guardedTaskRepository) Restore(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Restore(id)
+}
+
+func (r *guardedTaskRepository) GetTrashed(id string) (*repository.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetTrashed(id)
+}
+
+func (r *guardedTaskRepository) Search(filter repository.TaskFilter, search repository.TaskSearch) ([]repository.TaskSearchHit, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Search(filter, search)
+}
+
+type guardedUserRepository struct {
+	mu   *sync.Mutex
+	repo repository.UserRepository
+}
+
+func (r *guardedUserRepository) Create(user repository.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Create(user)
+}
+
+func (r *guardedUserRepository) GetByID(id string) (*repository.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetByID(id)
+}
+
+func (r *guardedUserRepository) GetByEmail(email string) (*repository.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetByEmail(email)
+}
+
+func (r *guardedUserRepository) GetAll() ([]repository.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetAll()
+}
+
+func (r *guardedUserRepository) GetByWorkspaceID(workspaceID string) ([]repository.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetByWorkspaceID(workspaceID)
+}
+
+func (r *guardedUserRepository) Update(user repository.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Update(user)
+}
+
+func (r *guardedUserRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Delete(id)
+}
+
+type guardedTagRepository struct {
+	mu   *sync.Mutex
+	repo repository.TagRepository
+}
+
+func (r *guardedTagRepository) Create(tag repository.Tag) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Create(tag)
+}
+
+func (r *guardedTagRepository) GetByID(id string) (*repository.Tag, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetByID(id)
+}
+
+func (r *guardedTagRepository) GetByUserID(userID, workspaceID string) ([]repository.Tag, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetByUserID(userID, workspaceID)
+}
+
+func (r *guardedTagRepository) Update(tag repository.Tag) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Update(tag)
+}
+
+func (r *guardedTagRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Delete(id)
+}
+
+type guardedProjectRepository struct {
+	mu   *sync.Mutex
+	repo repository.ProjectRepository
+}
+
+func (r *guardedProjectRepository) Create(project repository.Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Create(project)
+}
+
+func (r *guardedProjectRepository) GetByID(id string) (*repository.Project, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetByID(id)
+}
+
+func (r *guardedProjectRepository) GetByUserID(userID, workspaceID string) ([]repository.Project, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetByUserID(userID, workspaceID)
+}
+
+func (r *guardedProjectRepository) GetSharedWithUser(userID, workspaceID string) ([]repository.Project, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetSharedWithUser(userID, workspaceID)
+}
+
+func (r *guardedProjectRepository) Update(project repository.Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Update(project)
+}
+
+func (r *guardedProjectRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Delete(id)
+}
+
+func (r *guardedProjectRepository) SaveMember(member repository.ProjectMember) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.SaveMember(member)
+}
+
+func (r *guardedProjectRepository) GetMember(projectID, userID string) (*repository.ProjectMember, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetMember(projectID, userID)
+}
+
+func (r *guardedProjectRepository) GetMembers(projectID string) ([]repository.ProjectMember, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetMembers(projectID)
+}
+
+func (r *guardedProjectRepository) RemoveMember(projectID, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.RemoveMember(projectID, userID)
+}
+
+type guardedCommentRepository struct {
+	mu   *sync.Mutex
+	repo repository.CommentRepository
+}
+
+func (r *guardedCommentRepository) Create(comment repository.Comment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Create(comment)
+}
+
+func (r *guardedCommentRepository) GetByID(id string) (*repository.Comment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetByID(id)
+}
+
+func (r *guardedCommentRepository) GetByTaskID(taskID string, limit, offset int) ([]repository.Comment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetByTaskID(taskID, limit, offset)
+}
+
+func (r *guardedCommentRepository) CountByTaskID(taskID string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.CountByTaskID(taskID)
+}
+
+func (r *guardedCommentRepository) Update(comment repository.Comment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Update(comment)
+}
+
+func (r *guardedCommentRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Delete(id)
+}
+
+type guardedAttachmentRepository struct {
+	mu   *sync.Mutex
+	repo repository.AttachmentRepository
+}
+
+func (r *guardedAttachmentRepository) Create(attachment repository.Attachment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Create(attachment)
+}
+
+func (r *guardedAttachmentRepository) GetByID(id string) (*repository.Attachment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetByID(id)
+}
+
+func (r *guardedAttachmentRepository) GetByTaskID(taskID string) ([]repository.Attachment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetByTaskID(taskID)
+}
+
+func (r *guardedAttachmentRepository) CountByChecksum(checksum string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.CountByChecksum(checksum)
+}
+
+func (r *guardedAttachmentRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Delete(id)
+}
+
+type guardedWorkspaceRepository struct {
+	mu   *sync.Mutex
+	repo repository.WorkspaceRepository
+}
+
+func (r *guardedWorkspaceRepository) Create(workspace repository.Workspace) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Create(workspace)
+}
+
+func (r *guardedWorkspaceRepository) GetByID(id string) (*repository.Workspace, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetByID(id)
+}
+
+func (r *guardedWorkspaceRepository) GetByUserID(userID string) ([]repository.Workspace, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetByUserID(userID)
+}
+
+func (r *guardedWorkspaceRepository) SaveMember(member repository.WorkspaceMember) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.SaveMember(member)
+}
+
+func (r *guardedWorkspaceRepository) GetMember(workspaceID, userID string) (*repository.WorkspaceMember, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetMember(workspaceID, userID)
+}
+
+func (r *guardedWorkspaceRepository) GetMembers(workspaceID string) ([]repository.WorkspaceMember, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetMembers(workspaceID)
+}
+
+func (r *guardedWorkspaceRepository) SaveInvitation(invitation repository.WorkspaceInvitation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.SaveInvitation(invitation)
+}
+
+func (r *guardedWorkspaceRepository) GetInvitation(id string) (*repository.WorkspaceInvitation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetInvitation(id)
+}
+
+func (r *guardedWorkspaceRepository) GetInvitationsByUserID(userID string) ([]repository.WorkspaceInvitation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetInvitationsByUserID(userID)
+}
+
+func (r *guardedWorkspaceRepository) DeleteInvitation(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.DeleteInvitation(id)
+}
+
+type guardedTransitionRepository struct {
+	mu   *sync.Mutex
+	repo repository.TransitionRepository
+}
+
+func (r *guardedTransitionRepository) Create(transition repository.TaskTransition) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Create(transition)
+}
+
+func (r *guardedTransitionRepository) GetByTaskID(taskID string) ([]repository.TaskTransition, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetByTaskID(taskID)
+}
+
+type guardedWorkflowRepository struct {
+	mu   *sync.Mutex
+	repo repository.WorkflowRepository
+}
+
+func (r *guardedWorkflowRepository) GetStatuses(projectID string) ([]repository.WorkflowStatus, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetStatuses(projectID)
+}
+
+func (r *guardedWorkflowRepository) GetStatus(id string) (*repository.WorkflowStatus, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetStatus(id)
+}
+
+func (r *guardedWorkflowRepository) GetTransitions(projectID string) ([]repository.WorkflowTransition, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetTransitions(projectID)
+}
+
+func (r *guardedWorkflowRepository) SaveWorkflow(projectID string, statuses []repository.WorkflowStatus, transitions []repository.WorkflowTransition) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.SaveWorkflow(projectID, statuses, transitions)
+}
+
+func (r *guardedWorkflowRepository) DeleteStatus(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.DeleteStatus(id)
+}
+
+type guardedHistoryRepository struct {
+	mu   *sync.Mutex
+	repo repository.HistoryRepository
+}
+
+func (r *guardedHistoryRepository) Create(entry repository.TaskHistoryEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Create(entry)
+}
+
+func (r *guardedHistoryRepository) GetByTaskID(taskID string) ([]repository.TaskHistoryEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetByTaskID(taskID)
+}
+
+type guardedTimeEntryRepository struct {
+	mu   *sync.Mutex
+	repo repository.TimeEntryRepository
+}
+
+func (r *guardedTimeEntryRepository) Create(entry repository.TimeEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Create(entry)
+}
+
+func (r *guardedTimeEntryRepository) GetByID(id string) (*repository.TimeEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetByID(id)
+}
+
+func (r *guardedTimeEntryRepository) GetRunning(userID string) (*repository.TimeEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetRunning(userID)
+}
+
+func (r *guardedTimeEntryRepository) List(filter repository.TimeEntryFilter) ([]repository.TimeEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.List(filter)
+}
+
+func (r *guardedTimeEntryRepository) Update(entry repository.TimeEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Update(entry)
+}
+
+func (r *guardedTimeEntryRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Delete(id)
+}
+
+type guardedViewRepository struct {
+	mu   *sync.Mutex
+	repo repository.ViewRepository
+}
+
+func (r *guardedViewRepository) Create(view repository.View) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Create(view)
+}
+
+func (r *guardedViewRepository) GetByID(id string) (*repository.View, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetByID(id)
+}
+
+func (r *guardedViewRepository) GetByUserID(userID, workspaceID string) ([]repository.View, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetByUserID(userID, workspaceID)
+}
+
+func (r *guardedViewRepository) GetSharedWithUser(userID, workspaceID string) ([]repository.View, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetSharedWithUser(userID, workspaceID)
+}
+
+func (r *guardedViewRepository) Update(view repository.View) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Update(view)
+}
+
+func (r *guardedViewRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Delete(id)
+}
+
+func (r *guardedViewRepository) SetShares(viewID string, userIDs []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.SetShares(viewID, userIDs)
+}
+
+func (r *guardedViewRepository) GetShares(viewID string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetShares(viewID)
+}
+
+type guardedTemplateRepository struct {
+	mu   *sync.Mutex
+	repo repository.TemplateRepository
+}
+
+func (r *guardedTemplateRepository) Create(template repository.Template) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Create(template)
+}
+
+func (r *guardedTemplateRepository) GetByID(id string) (*repository.Template, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetByID(id)
+}
+
+func (r *guardedTemplateRepository) GetVisible(userID, workspaceID string) ([]repository.Template, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.GetVisible(userID, workspaceID)
+}
+
+func (r *guardedTemplateRepository) Update(template repository.Template) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Update(template)
+}
+
+func (r *guardedTemplateRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repo.Delete(id)
+}
@@ -0,0 +1,133 @@
+package memory
+
+import (
+	"maps"
+	"sort"
+	"todo-api/internal/repository"
+)
+
+type projectRepository struct {
+	projects map[string]repository.Project
+	members  map[string]map[string]repository.ProjectMember
+}
+
+func NewProjectRepository() repository.ProjectRepository {
+	return &projectRepository{
+		projects: make(map[string]repository.Project),
+		members:  make(map[string]map[string]repository.ProjectMember),
+	}
+}
+
+func (r *projectRepository) Create(project repository.Project) error {
+	r.projects[project.ID] = project
+	return nil
+}
+
+func (r *projectRepository) GetByID(id string) (*repository.Project, error) {
+	project, exists := r.projects[id]
+	if !exists {
+		return nil, nil
+	}
+
+	return &project, nil
+}
+
+func (r *projectRepository) GetByUserID(userID, workspaceID string) ([]repository.Project, error) {
+	var userProjects []repository.Project
+	for _, project := range r.projects {
+		if project.UserID == userID && project.WorkspaceID == workspaceID {
+			userProjects = append(userProjects, project)
+		}
+	}
+
+	sortProjects(userProjects)
+
+	return userProjects, nil
+}
+
+func (r *projectRepository) GetSharedWithUser(userID, workspaceID string) ([]repository.Project, error) {
+	var sharedProjects []repository.Project
+	for projectID, members := range r.members {
+		if _, isMember := members[userID]; !isMember {
+			continue
+		}
+
+		if project, exists := r.projects[projectID]; exists && project.WorkspaceID == workspaceID {
+			sharedProjects = append(sharedProjects, project)
+		}
+	}
+
+	sortProjects(sharedProjects)
+
+	return sharedProjects, nil
+}
+
+func sortProjects(projects []repository.Project) {
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].CreatedAt.Before(projects[j].CreatedAt)
+	})
+}
+
+func (r *projectRepository) Update(project repository.Project) error {
+	if _, exists := r.projects[project.ID]; !exists {
+		return nil
+	}
+
+	r.projects[project.ID] = project
+	return nil
+}
+
+func (r *projectRepository) Delete(id string) error {
+	delete(r.projects, id)
+	delete(r.members, id)
+	return nil
+}
+
+func (r *projectRepository) SaveMember(member repository.ProjectMember) error {
+	if r.members[member.ProjectID] == nil {
+		r.members[member.ProjectID] = make(map[string]repository.ProjectMember)
+	}
+
+	if existing, exists := r.members[member.ProjectID][member.UserID]; exists {
+		member.CreatedAt = existing.CreatedAt
+	}
+
+	r.members[member.ProjectID][member.UserID] = member
+	return nil
+}
+
+func (r *projectRepository) GetMember(projectID, userID string) (*repository.ProjectMember, error) {
+	member, exists := r.members[projectID][userID]
+	if !exists {
+		return nil, nil
+	}
+
+	return &member, nil
+}
+
+func (r *projectRepository) GetMembers(projectID string) ([]repository.ProjectMember, error) {
+	var members []repository.ProjectMember
+	for _, member := range r.members[projectID] {
+		members = append(members, member)
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].CreatedAt.Before(members[j].CreatedAt)
+	})
+
+	return members, nil
+}
+
+func (r *projectRepository) RemoveMember(projectID, userID string) error {
+	delete(r.members[projectID], userID)
+	return nil
+}
+
+func (r *projectRepository) snapshot() func() {
+	projects := maps.Clone(r.projects)
+	members := copyNested(r.members)
+
+	return func() {
+		r.projects, r.members = projects, members
+	}
+}
@@ -0,0 +1,261 @@
+package memory
+
+import (
+	"html"
+	"math"
+	"sort"
+	"strings"
+	"todo-api/internal/repository"
+	"unicode"
+)
+
+const (
+	titleWeight       = 1.0
+	descriptionWeight = 0.4
+	snippetWords      = 20
+	snippetLead       = 5
+)
+
+// searchIndex is an inverted index from the words of task titles and
+// descriptions to the positions they occur at. Description positions follow
+// the title ones with a gap, so phrases never span the two.
+type searchIndex struct {
+	postings   map[string]map[string][]int
+	docWords   map[string][]string
+	titleWords map[string]int
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		postings:   make(map[string]map[string][]int),
+		docWords:   make(map[string][]string),
+		titleWords: make(map[string]int),
+	}
+}
+
+// wordSpan is the byte range of a word in a text.
+type wordSpan struct {
+	start, end int
+}
+
+func wordSpans(text string) []wordSpan {
+	var spans []wordSpan
+	start := -1
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case isWord && start < 0:
+			start = i
+		case !isWord && start >= 0:
+			spans = append(spans, wordSpan{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, wordSpan{start, len(text)})
+	}
+	return spans
+}
+
+func tokenize(text string) []string {
+	spans := wordSpans(text)
+	words := make([]string, len(spans))
+	for i, span := range spans {
+		words[i] = strings.ToLower(text[span.start:span.end])
+	}
+	return words
+}
+
+func (idx *searchIndex) add(id, title, description string) {
+	idx.remove(id)
+
+	titleWords := tokenize(title)
+	words := append(titleWords, "")
+	words = append(words, tokenize(description)...)
+
+	for pos, word := range words {
+		if word == "" {
+			continue
+		}
+		if idx.postings[word] == nil {
+			idx.postings[word] = make(map[string][]int)
+		}
+		idx.postings[word][id] = append(idx.postings[word][id], pos)
+	}
+
+	idx.docWords[id] = words
+	idx.titleWords[id] = len(titleWords)
+}
+
+func (idx *searchIndex) remove(id string) {
+	for _, word := range idx.docWords[id] {
+		delete(idx.postings[word], id)
+		if len(idx.postings[word]) == 0 {
+			delete(idx.postings, word)
+		}
+	}
+
+	delete(idx.docWords, id)
+	delete(idx.titleWords, id)
+}
+
+// positions lists where word occurs in each task; a prefix matches every
+// indexed word starting with it.
+func (idx *searchIndex) positions(word string, prefix bool) map[string][]int {
+	if !prefix {
+		return idx.postings[word]
+	}
+
+	matches := make(map[string][]int)
+	for indexed, docs := range idx.postings {
+		if !strings.HasPrefix(indexed, word) {
+			continue
+		}
+		for id, positions := range docs {
+			matches[id] = append(matches[id], positions...)
+		}
+	}
+	return matches
+}
+
+// match returns the positions at which term starts in each matching task.
+func (idx *searchIndex) match(term repository.SearchTerm) map[string][]int {
+	last := len(term.Words) - 1
+	starts := idx.positions(term.Words[0], term.Prefix && last == 0)
+
+	for i := 1; i <= last; i++ {
+		next := idx.positions(term.Words[i], term.Prefix && i == last)
+		filtered := make(map[string][]int)
+		for id, positions := range starts {
+			for _, pos := range positions {
+				if containsInt(next[id], pos+i) {
+					filtered[id] = append(filtered[id], pos)
+				}
+			}
+		}
+		starts = filtered
+	}
+
+	return starts
+}
+
+// search ranks the tasks matching every term. Each match counts for more in
+// the title than in the description, and long texts are slightly penalised.
+func (idx *searchIndex) search(terms []repository.SearchTerm) map[string]float64 {
+	ranks := make(map[string]float64)
+	for i, term := range terms {
+		matches := idx.match(term)
+		next := make(map[string]float64)
+		for id, positions := range matches {
+			if i > 0 {
+				if _, ok := ranks[id]; !ok {
+					continue
+				}
+			}
+
+			score := ranks[id]
+			for _, pos := range positions {
+				if pos < idx.titleWords[id] {
+					score += titleWeight
+				} else {
+					score += descriptionWeight
+				}
+			}
+			next[id] = score
+		}
+		ranks = next
+	}
+
+	for id, score := range ranks {
+		ranks[id] = score / (1 + math.Log(float64(1+len(idx.docWords[id]))))
+	}
+
+	return ranks
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// searchSnippet returns a stretch of text around the first match with every
+// matching word wrapped in <mark> tags. The text itself is HTML-escaped.
+func searchSnippet(text string, terms []repository.SearchTerm) string {
+	spans := wordSpans(text)
+	if len(spans) == 0 {
+		return html.EscapeString(text)
+	}
+
+	marked := make([]bool, len(spans))
+	first := -1
+	for i, span := range spans {
+		word := strings.ToLower(text[span.start:span.end])
+		if matchesTermWord(word, terms) {
+			marked[i] = true
+			if first < 0 {
+				first = i
+			}
+		}
+	}
+
+	from := 0
+	if first > snippetLead {
+		from = first - snippetLead
+	}
+	to := from + snippetWords
+	if to > len(spans) {
+		to = len(spans)
+	}
+
+	start, end := spans[from].start, spans[to-1].end
+	if from == 0 {
+		start = 0
+	}
+	if to == len(spans) {
+		end = len(text)
+	}
+
+	var b strings.Builder
+	pos := start
+	for i := from; i < to; i++ {
+		if !marked[i] {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[pos:spans[i].start]))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(text[spans[i].start:spans[i].end]))
+		b.WriteString("</mark>")
+		pos = spans[i].end
+	}
+	b.WriteString(html.EscapeString(text[pos:end]))
+
+	return strings.TrimSpace(b.String())
+}
+
+func matchesTermWord(word string, terms []repository.SearchTerm) bool {
+	for _, term := range terms {
+		for i, termWord := range term.Words {
+			if word == termWord || (term.Prefix && i == len(term.Words)-1 && strings.HasPrefix(word, termWord)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func sortSearchHits(hits []repository.TaskSearchHit) {
+	sort.Slice(hits, func(i, j int) bool {
+		a, b := hits[i], hits[j]
+		if a.Rank != b.Rank {
+			return a.Rank > b.Rank
+		}
+		if !a.Task.CreatedAt.Equal(b.Task.CreatedAt) {
+			return a.Task.CreatedAt.After(b.Task.CreatedAt)
+		}
+		return a.Task.ID < b.Task.ID
+	})
+}
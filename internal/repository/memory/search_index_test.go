@@ -0,0 +1,29 @@
+package memory
+
+import (
+	"testing"
+	"todo-api/internal/repository"
+)
+
+func TestSearchSnippet(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		terms []repository.SearchTerm
+		want  string
+	}{
+		{"marks matches", "Fix the login page", []repository.SearchTerm{{Words: []string{"login"}}}, "Fix the <mark>login</mark> page"},
+		{"marks prefixes", "Deploy on Friday", []repository.SearchTerm{{Words: []string{"dep"}, Prefix: true}}, "<mark>Deploy</mark> on Friday"},
+		{"escapes text", `<b>report</b> & "notes"`, []repository.SearchTerm{{Words: []string{"report"}}},
+			`&lt;b&gt;<mark>report</mark>&lt;/b&gt; &amp; &#34;notes&#34;`},
+		{"escapes text without words", "<>&", nil, "&lt;&gt;&amp;"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := searchSnippet(tt.text, tt.terms); got != tt.want {
+				t.Errorf("searchSnippet(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package memory
+
+import (
+	"sync"
+	"todo-api/internal/repository"
+)
+
+// NewRepository wires the repositories of one in-memory store. They share a
+// lock: every call takes it, and a transaction holds it from its snapshot
+// until it commits or rolls back.
+func NewRepository() *repository.Repository {
+	mu := &sync.Mutex{}
+
+	tagRepo := NewTagRepository()
+	workspaceRepo := NewWorkspaceRepository()
+	workflowRepo := NewWorkflowRepository()
+	repos := repository.Repository{
+		Task:       NewTaskRepository(tagRepo, workflowRepo),
+		User:       NewUserRepository(workspaceRepo),
+		Tag:        tagRepo,
+		Project:    NewProjectRepository(),
+		Comment:    NewCommentRepository(),
+		Attachment: NewAttachmentRepository(),
+		Workspace:  workspaceRepo,
+		Transition: NewTransitionRepository(),
+		Workflow:   workflowRepo,
+		History:    NewHistoryRepository(),
+		TimeEntry:  NewTimeEntryRepository(),
+		View:       NewViewRepository(),
+		Template:   NewTemplateRepository(),
+	}
+
+	return &repository.Repository{
+		Task:       &guardedTaskRepository{mu: mu, repo: repos.Task},
+		User:       &guardedUserRepository{mu: mu, repo: repos.User},
+		Tag:        &guardedTagRepository{mu: mu, repo: repos.Tag},
+		Project:    &guardedProjectRepository{mu: mu, repo: repos.Project},
+		Comment:    &guardedCommentRepository{mu: mu, repo: repos.Comment},
+		Attachment: &guardedAttachmentRepository{mu: mu, repo: repos.Attachment},
+		Workspace:  &guardedWorkspaceRepository{mu: mu, repo: repos.Workspace},
+		Transition: &guardedTransitionRepository{mu: mu, repo: repos.Transition},
+		Workflow:   &guardedWorkflowRepository{mu: mu, repo: repos.Workflow},
+		History:    &guardedHistoryRepository{mu: mu, repo: repos.History},
+		TimeEntry:  &guardedTimeEntryRepository{mu: mu, repo: repos.TimeEntry},
+		View:       &guardedViewRepository{mu: mu, repo: repos.View},
+		Template:   &guardedTemplateRepository{mu: mu, repo: repos.Template},
+		Transactor: &transactor{mu: mu, repos: repos},
+	}
+}
@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"maps"
+	"sort"
+	"todo-api/internal/repository"
+)
+
+type tagRepository struct {
+	tags map[string]repository.Tag
+}
+
+func NewTagRepository() repository.TagRepository {
+	return &tagRepository{
+		tags: make(map[string]repository.Tag),
+	}
+}
+
+func (r *tagRepository) Create(tag repository.Tag) error {
+	r.tags[tag.ID] = tag
+	return nil
+}
+
+func (r *tagRepository) GetByID(id string) (*repository.Tag, error) {
+	tag, exists := r.tags[id]
+	if !exists {
+		return nil, nil
+	}
+
+	return &tag, nil
+}
+
+func (r *tagRepository) GetByUserID(userID, workspaceID string) ([]repository.Tag, error) {
+	var userTags []repository.Tag
+	for _, tag := range r.tags {
+		if tag.UserID == userID && tag.WorkspaceID == workspaceID {
+			userTags = append(userTags, tag)
+		}
+	}
+
+	sort.Slice(userTags, func(i, j int) bool {
+		return userTags[i].Name < userTags[j].Name
+	})
+
+	return userTags, nil
+}
+
+func (r *tagRepository) Update(tag repository.Tag) error {
+	if _, exists := r.tags[tag.ID]; !exists {
+		return nil
+	}
+
+	r.tags[tag.ID] = tag
+	return nil
+}
+
+func (r *tagRepository) Delete(id string) error {
+	delete(r.tags, id)
+	return nil
+}
+
+func (r *tagRepository) snapshot() func() {
+	tags := maps.Clone(r.tags)
+
+	return func() {
+		r.tags = tags
+	}
+}
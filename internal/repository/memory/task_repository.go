@@ -1,47 +1,314 @@
 package memory
 
 import (
+	"maps"
+	"sort"
+	"strings"
+	"time"
 	"todo-api/internal/repository"
 )
 
 type taskRepository struct {
-	tasks map[string]repository.Task
+	tasks        map[string]repository.Task
+	taskTags     map[string]map[string]bool
+	dependencies map[string]map[string]bool
+	index        *searchIndex
+	tagRepo      repository.TagRepository
+	workflowRepo repository.WorkflowRepository
 }
 
-func NewTaskRepository() repository.TaskRepository {
+func NewTaskRepository(tagRepo repository.TagRepository, workflowRepo repository.WorkflowRepository) repository.TaskRepository {
 	return &taskRepository{
-		tasks: make(map[string]repository.Task),
+		tasks:        make(map[string]repository.Task),
+		taskTags:     make(map[string]map[string]bool),
+		dependencies: make(map[string]map[string]bool),
+		index:        newSearchIndex(),
+		tagRepo:      tagRepo,
+		workflowRepo: workflowRepo,
 	}
 }
 
 func (r *taskRepository) Create(task repository.Task) error {
+	task.Tags, task.BlockedBy, task.Blocks = nil, nil, nil
 	r.tasks[task.ID] = task
+	r.index.add(task.ID, task.Title, task.Description)
 	return nil
 }
 
 func (r *taskRepository) GetByID(id string) (*repository.Task, error) {
 	task, exists := r.tasks[id]
-	if !exists {
+	if !exists || task.DeletedAt != nil {
 		return nil, nil
 	}
 
+	task = r.hydrate(task)
 	return &task, nil
 }
 
-func (r *taskRepository) GetAll() ([]repository.Task, error) {
-	tasks := make([]repository.Task, 0, len(r.tasks))
+func (r *taskRepository) List(filter repository.TaskFilter) ([]repository.Task, error) {
+	var tasks []repository.Task
 	for _, task := range r.tasks {
-		tasks = append(tasks, task)
+		task = r.withStatus(task)
+		if matchesFilter(task, filter) && r.hasTags(task, filter) {
+			tasks = append(tasks, task)
+		}
+	}
+
+	sortTasks(tasks, filter.Sort)
+
+	if filter.After != nil {
+		start := sort.Search(len(tasks), func(i int) bool {
+			return taskLess(*filter.After, tasks[i], filter.Sort)
+		})
+		tasks = tasks[start:]
+	}
+
+	if filter.Limit > 0 && len(tasks) > filter.Limit {
+		tasks = tasks[:filter.Limit]
+	}
+
+	for i := range tasks {
+		tasks[i] = r.withRelations(tasks[i])
 	}
 
 	return tasks, nil
 }
 
+func (r *taskRepository) Search(filter repository.TaskFilter, search repository.TaskSearch) ([]repository.TaskSearchHit, error) {
+	var hits []repository.TaskSearchHit
+	for id, rank := range r.index.search(search.Terms) {
+		task := r.withStatus(r.tasks[id])
+		if !matchesFilter(task, filter) || !r.hasTags(task, filter) {
+			continue
+		}
+
+		hits = append(hits, repository.TaskSearchHit{
+			Task:    task,
+			Rank:    rank,
+			Snippet: searchSnippet(task.Title+" "+task.Description, search.Terms),
+		})
+	}
+
+	sortSearchHits(hits)
+	if search.Limit > 0 && len(hits) > search.Limit {
+		hits = hits[:search.Limit]
+	}
+
+	for i := range hits {
+		hits[i].Task = r.withRelations(hits[i].Task)
+	}
+
+	return hits, nil
+}
+
+func sortTasks(tasks []repository.Task, keys []repository.SortKey) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return taskLess(tasks[i], tasks[j], keys)
+	})
+}
+
+// taskLess reports whether a is listed before b.
+func taskLess(a, b repository.Task, keys []repository.SortKey) bool {
+	for _, key := range keys {
+		cmp := compareTasks(a, b, key.Field)
+		if cmp == 0 {
+			continue
+		}
+		if key.Field == repository.SortByDue && (a.DueAt == nil || b.DueAt == nil) {
+			return a.DueAt != nil
+		}
+		if key.Field == repository.SortByCompleted && (a.CompletedAt == nil || b.CompletedAt == nil) {
+			return a.CompletedAt != nil
+		}
+		if key.Desc {
+			return cmp > 0
+		}
+		return cmp < 0
+	}
+
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+	return a.ID > b.ID
+}
+
+func compareTasks(a, b repository.Task, field string) int {
+	switch field {
+	case repository.SortByCreated:
+		return a.CreatedAt.Compare(b.CreatedAt)
+	case repository.SortByDue:
+		switch {
+		case a.DueAt == nil && b.DueAt == nil:
+			return 0
+		case a.DueAt == nil:
+			return 1
+		case b.DueAt == nil:
+			return -1
+		}
+		return a.DueAt.Compare(*b.DueAt)
+	case repository.SortByPriority:
+		return a.Priority - b.Priority
+	case repository.SortByTitle:
+		return strings.Compare(a.Title, b.Title)
+	case repository.SortByStatus:
+		return a.StatusPosition - b.StatusPosition
+	case repository.SortByCompleted:
+		switch {
+		case a.CompletedAt == nil && b.CompletedAt == nil:
+			return 0
+		case a.CompletedAt == nil:
+			return 1
+		case b.CompletedAt == nil:
+			return -1
+		}
+		return a.CompletedAt.Compare(*b.CompletedAt)
+	case repository.SortByDeleted:
+		if a.DeletedAt == nil || b.DeletedAt == nil {
+			return 0
+		}
+		return a.DeletedAt.Compare(*b.DeletedAt)
+	case repository.SortByPosition:
+		switch {
+		case a.BoardPosition < b.BoardPosition:
+			return -1
+		case a.BoardPosition > b.BoardPosition:
+			return 1
+		}
+		return 0
+	}
+	return 0
+}
+
+func matchesFilter(task repository.Task, filter repository.TaskFilter) bool {
+	if (task.DeletedAt != nil) != filter.Trashed {
+		return false
+	}
+
+	if filter.DeletedBefore != nil && (task.DeletedAt == nil || !task.DeletedAt.Before(*filter.DeletedBefore)) {
+		return false
+	}
+
+	if filter.WorkspaceID != "" && task.WorkspaceID != filter.WorkspaceID {
+		return false
+	}
+
+	if filter.ProjectID != nil && task.ProjectID != *filter.ProjectID {
+		return false
+	}
+
+	if filter.AssigneeID != nil && task.AssigneeID != *filter.AssigneeID {
+		return false
+	}
+
+	if filter.ParentID != nil && task.ParentID != *filter.ParentID {
+		return false
+	}
+
+	if filter.StatusID != "" && task.StatusID != filter.StatusID {
+		return false
+	}
+
+	if filter.Status != "" && !strings.EqualFold(task.Status, filter.Status) {
+		return false
+	}
+
+	if filter.UserID != "" && task.UserID != filter.UserID {
+		return false
+	}
+
+	if filter.VisibleTo != "" && task.UserID != filter.VisibleTo && task.AssigneeID != filter.VisibleTo &&
+		(task.ProjectID == "" || !containsString(filter.VisibleProjectIDs, task.ProjectID)) {
+		return false
+	}
+
+	if filter.DueBefore != nil && (task.DueAt == nil || !task.DueAt.Before(*filter.DueBefore)) {
+		return false
+	}
+
+	if filter.DueAfter != nil && (task.DueAt == nil || task.DueAt.Before(*filter.DueAfter)) {
+		return false
+	}
+
+	if filter.CreatedBefore != nil && !task.CreatedAt.Before(*filter.CreatedBefore) {
+		return false
+	}
+
+	if filter.CreatedAfter != nil && task.CreatedAt.Before(*filter.CreatedAfter) {
+		return false
+	}
+
+	if filter.Text != "" && !containsFold(task.Title, filter.Text) && !containsFold(task.Description, filter.Text) {
+		return false
+	}
+
+	if filter.ExcludeDone && task.Done {
+		return false
+	}
+
+	if filter.OnlyDone && !task.Done {
+		return false
+	}
+
+	if filter.CompletedBefore != nil && (task.CompletedAt == nil || !task.CompletedAt.Before(*filter.CompletedBefore)) {
+		return false
+	}
+
+	if filter.CompletedAfter != nil && (task.CompletedAt == nil || task.CompletedAt.Before(*filter.CompletedAfter)) {
+		return false
+	}
+
+	if filter.ExcludeArchived && task.ArchivedAt != nil {
+		return false
+	}
+
+	return true
+}
+
+// hasTags reports whether a task carries the tags a listing is filtered by.
+// Only the tags of filter.TagUserID in the task's workspace count.
+func (r *taskRepository) hasTags(task repository.Task, filter repository.TaskFilter) bool {
+	if len(filter.Tags) == 0 {
+		return true
+	}
+
+	var names []string
+	for tagID := range r.taskTags[task.ID] {
+		tag, _ := r.tagRepo.GetByID(tagID)
+		if tag != nil && tag.UserID == filter.TagUserID && tag.WorkspaceID == task.WorkspaceID {
+			names = append(names, tag.Name)
+		}
+	}
+
+	matched := 0
+	for _, tag := range filter.Tags {
+		if containsString(names, tag) {
+			matched++
+		} else if filter.MatchAllTags {
+			return false
+		}
+	}
+
+	return matched > 0
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *taskRepository) GetByUserID(userID string) ([]repository.Task, error) {
 	var userTasks []repository.Task
 	for _, task := range r.tasks {
-		if task.UserID == userID {
-			userTasks = append(userTasks, task)
+		if task.UserID == userID && task.DeletedAt == nil {
+			userTasks = append(userTasks, r.hydrate(task))
 		}
 	}
 
@@ -53,11 +320,281 @@ func (r *taskRepository) Update(task repository.Task) error {
 		return nil
 	}
 
+	task.Tags, task.BlockedBy, task.Blocks = nil, nil, nil
 	r.tasks[task.ID] = task
+	r.index.add(task.ID, task.Title, task.Description)
 	return nil
 }
 
+func (r *taskRepository) GetChildren(parentID string) ([]repository.Task, error) {
+	var children []repository.Task
+	for _, task := range r.tasks {
+		if task.ParentID == parentID && task.DeletedAt == nil {
+			children = append(children, r.hydrate(task))
+		}
+	}
+
+	sort.Slice(children, func(i, j int) bool {
+		if !children[i].CreatedAt.Equal(children[j].CreatedAt) {
+			return children[i].CreatedAt.Before(children[j].CreatedAt)
+		}
+		return children[i].ID < children[j].ID
+	})
+
+	return children, nil
+}
+
 func (r *taskRepository) Delete(id string) error {
 	delete(r.tasks, id)
+	r.index.remove(id)
+	delete(r.taskTags, id)
+	delete(r.dependencies, id)
+	for _, dependsOn := range r.dependencies {
+		delete(dependsOn, id)
+	}
+
+	for childID, task := range r.tasks {
+		if task.ParentID == id {
+			r.Delete(childID)
+		}
+	}
+
 	return nil
 }
+
+func (r *taskRepository) MoveProjectTasks(fromProjectID, toProjectID string) error {
+	for id, task := range r.tasks {
+		if task.ProjectID == fromProjectID {
+			task.ProjectID = toProjectID
+			r.tasks[id] = task
+		}
+	}
+
+	return nil
+}
+
+func (r *taskRepository) ReplaceStatus(projectID, fromStatusID, toStatusID string) error {
+	status, err := r.workflowRepo.GetStatus(toStatusID)
+	if err != nil || status == nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	for id, task := range r.tasks {
+		if task.ProjectID == projectID && task.StatusID == fromStatusID {
+			task.StatusID = toStatusID
+			if !status.Done {
+				task.CompletedAt = nil
+			} else if task.CompletedAt == nil {
+				task.CompletedAt = &now
+			}
+			r.tasks[id] = task
+		}
+	}
+
+	return nil
+}
+
+func (r *taskRepository) DeleteByProjectID(projectID string) error {
+	for id, task := range r.tasks {
+		if task.ProjectID == projectID {
+			r.Delete(id)
+		}
+	}
+
+	return nil
+}
+
+func (r *taskRepository) AddTag(taskID, tagID string) error {
+	if r.taskTags[taskID] == nil {
+		r.taskTags[taskID] = make(map[string]bool)
+	}
+
+	r.taskTags[taskID][tagID] = true
+	return nil
+}
+
+func (r *taskRepository) RemoveTag(taskID, tagID string) error {
+	delete(r.taskTags[taskID], tagID)
+	return nil
+}
+
+func (r *taskRepository) GetTags(taskID string) ([]repository.Tag, error) {
+	var tags []repository.Tag
+	for tagID := range r.taskTags[taskID] {
+		tag, err := r.tagRepo.GetByID(tagID)
+		if err != nil {
+			return nil, err
+		}
+		if tag != nil {
+			tags = append(tags, *tag)
+		}
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Name < tags[j].Name
+	})
+
+	return tags, nil
+}
+
+func (r *taskRepository) AddDependency(taskID, dependsOnID string) error {
+	if r.dependencies[taskID] == nil {
+		r.dependencies[taskID] = make(map[string]bool)
+	}
+
+	r.dependencies[taskID][dependsOnID] = true
+	return nil
+}
+
+func (r *taskRepository) RemoveDependency(taskID, dependsOnID string) error {
+	delete(r.dependencies[taskID], dependsOnID)
+	return nil
+}
+
+func (r *taskRepository) GetDependencies(taskID string) ([]repository.Task, error) {
+	var tasks []repository.Task
+	for dependsOnID := range r.dependencies[taskID] {
+		if task, exists := r.tasks[dependsOnID]; exists && task.DeletedAt == nil {
+			tasks = append(tasks, r.hydrate(task))
+		}
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].CreatedAt.Before(tasks[j].CreatedAt)
+	})
+
+	return tasks, nil
+}
+
+func (r *taskRepository) GetDependencyIDs(taskID string) ([]string, error) {
+	ids := []string{}
+	for dependsOnID := range r.dependencies[taskID] {
+		ids = append(ids, dependsOnID)
+	}
+
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// hydrate fills the relation fields that are kept outside of the task itself.
+func (r *taskRepository) hydrate(task repository.Task) repository.Task {
+	return r.withRelations(r.withStatus(task))
+}
+
+// withStatus fills the fields taken from the task's workflow status, which
+// listings are filtered and sorted by.
+func (r *taskRepository) withStatus(task repository.Task) repository.Task {
+	if status, _ := r.workflowRepo.GetStatus(task.StatusID); status != nil {
+		task.Status, task.StatusPosition, task.Done = status.Name, status.Position, status.Done
+	}
+
+	return task
+}
+
+// withRelations fills the tags and dependencies of a task.
+func (r *taskRepository) withRelations(task repository.Task) repository.Task {
+	tags, _ := r.GetTags(task.ID)
+
+	task.Tags = make([]string, len(tags))
+	for i, tag := range tags {
+		task.Tags[i] = tag.Name
+	}
+
+	task.BlockedBy = []string{}
+	for dependsOnID := range r.dependencies[task.ID] {
+		if r.isLive(dependsOnID) {
+			task.BlockedBy = append(task.BlockedBy, dependsOnID)
+		}
+	}
+	sort.Strings(task.BlockedBy)
+
+	task.Blocks = []string{}
+	for taskID, dependsOn := range r.dependencies {
+		if dependsOn[task.ID] && r.isLive(taskID) {
+			task.Blocks = append(task.Blocks, taskID)
+		}
+	}
+	sort.Strings(task.Blocks)
+
+	return task
+}
+
+func (r *taskRepository) isLive(id string) bool {
+	task, exists := r.tasks[id]
+	return exists && task.DeletedAt == nil
+}
+
+func (r *taskRepository) SoftDelete(id string, deletedAt time.Time) error {
+	task, exists := r.tasks[id]
+	if !exists || task.DeletedAt != nil {
+		return nil
+	}
+
+	task.DeletedAt = &deletedAt
+	r.tasks[id] = task
+
+	for childID, child := range r.tasks {
+		if child.ParentID == id {
+			r.SoftDelete(childID, deletedAt)
+		}
+	}
+
+	return nil
+}
+
+func (r *taskRepository) Restore(id string) error {
+	task, exists := r.tasks[id]
+	if !exists || task.DeletedAt == nil {
+		return nil
+	}
+
+	deletedAt := *task.DeletedAt
+	task.DeletedAt = nil
+	r.tasks[id] = task
+
+	for childID, child := range r.tasks {
+		if child.ParentID == id && child.DeletedAt != nil && child.DeletedAt.Equal(deletedAt) {
+			r.Restore(childID)
+		}
+	}
+
+	return nil
+}
+
+func (r *taskRepository) GetTrashed(id string) (*repository.Task, error) {
+	task, exists := r.tasks[id]
+	if !exists || task.DeletedAt == nil {
+		return nil, nil
+	}
+
+	task = r.hydrate(task)
+	return &task, nil
+}
+
+func (r *taskRepository) snapshot() func() {
+	tasks := make(map[string]repository.Task, len(r.tasks))
+	for id, task := range r.tasks {
+		tasks[id] = task
+	}
+	taskTags := copyNested(r.taskTags)
+	dependencies := copyNested(r.dependencies)
+
+	return func() {
+		r.tasks, r.taskTags, r.dependencies = tasks, taskTags, dependencies
+		r.index = newSearchIndex()
+		for id, task := range tasks {
+			r.index.add(id, task.Title, task.Description)
+		}
+	}
+}
+
+// copyNested copies a map of maps, such as the links between tasks, deeply
+// enough for a snapshot.
+func copyNested[V any](nested map[string]map[string]V) map[string]map[string]V {
+	copied := make(map[string]map[string]V, len(nested))
+	for id, inner := range nested {
+		copied[id] = maps.Clone(inner)
+	}
+	return copied
+}
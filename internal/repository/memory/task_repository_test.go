@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"fmt"
+	"testing"
+	"time"
+	"todo-api/internal/repository"
+)
+
+func TestListKeysetPages(t *testing.T) {
+	base := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	due := base.Add(24 * time.Hour)
+
+	repo := NewTaskRepository(NewTagRepository(), NewWorkflowRepository())
+	for i := 0; i < 9; i++ {
+		task := repository.Task{
+			ID:        fmt.Sprintf("task-%d", i),
+			Title:     fmt.Sprintf("Task %d", i%3),
+			Priority:  i % 2,
+			CreatedAt: base.Add(time.Duration(i/2) * time.Hour),
+		}
+		if i%3 == 0 {
+			task.DueAt = &due
+		}
+		if err := repo.Create(task); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		sort []repository.SortKey
+	}{
+		{"newest first", nil},
+		{"created ascending", []repository.SortKey{{Field: repository.SortByCreated}}},
+		{"due dates, missing last", []repository.SortKey{{Field: repository.SortByDue}}},
+		{"due dates descending", []repository.SortKey{{Field: repository.SortByDue, Desc: true}}},
+		{"priority then title", []repository.SortKey{{Field: repository.SortByPriority, Desc: true}, {Field: repository.SortByTitle}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			all, err := repo.List(repository.TaskFilter{Sort: tt.sort})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i := 1; i < len(all); i++ {
+				if !taskLess(all[i-1], all[i], tt.sort) {
+					t.Fatalf("tasks %s and %s are out of order", all[i-1].ID, all[i].ID)
+				}
+			}
+
+			var paged []repository.Task
+			filter := repository.TaskFilter{Sort: tt.sort, Limit: 2}
+			for {
+				page, err := repo.List(filter)
+				if err != nil {
+					t.Fatal(err)
+				}
+				paged = append(paged, page...)
+				if len(page) < filter.Limit {
+					break
+				}
+				filter.After = &page[len(page)-1]
+			}
+
+			if len(paged) != len(all) {
+				t.Fatalf("pages hold %d tasks, want %d", len(paged), len(all))
+			}
+			for i := range all {
+				if paged[i].ID != all[i].ID {
+					t.Errorf("paged task %d = %s, want %s", i, paged[i].ID, all[i].ID)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"maps"
+	"sort"
+	"todo-api/internal/repository"
+)
+
+type templateRepository struct {
+	templates map[string]repository.Template
+}
+
+func NewTemplateRepository() repository.TemplateRepository {
+	return &templateRepository{
+		templates: make(map[string]repository.Template),
+	}
+}
+
+func (r *templateRepository) Create(template repository.Template) error {
+	r.templates[template.ID] = template
+	return nil
+}
+
+func (r *templateRepository) GetByID(id string) (*repository.Template, error) {
+	template, exists := r.templates[id]
+	if !exists {
+		return nil, nil
+	}
+
+	return &template, nil
+}
+
+func (r *templateRepository) GetVisible(userID, workspaceID string) ([]repository.Template, error) {
+	var templates []repository.Template
+	for _, template := range r.templates {
+		if template.WorkspaceID == workspaceID && (template.UserID == userID || template.Shared) {
+			templates = append(templates, template)
+		}
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		a, b := templates[i], templates[j]
+		if ownA, ownB := a.UserID == userID, b.UserID == userID; ownA != ownB {
+			return ownA
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+
+	return templates, nil
+}
+
+func (r *templateRepository) Update(template repository.Template) error {
+	if _, exists := r.templates[template.ID]; !exists {
+		return nil
+	}
+
+	r.templates[template.ID] = template
+	return nil
+}
+
+func (r *templateRepository) Delete(id string) error {
+	delete(r.templates, id)
+	return nil
+}
+
+func (r *templateRepository) snapshot() func() {
+	templates := maps.Clone(r.templates)
+
+	return func() {
+		r.templates = templates
+	}
+}
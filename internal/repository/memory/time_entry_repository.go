@@ -0,0 +1,103 @@
+package memory
+
+import (
+	"maps"
+	"sort"
+	"todo-api/internal/repository"
+)
+
+type timeEntryRepository struct {
+	entries map[string]repository.TimeEntry
+}
+
+func NewTimeEntryRepository() repository.TimeEntryRepository {
+	return &timeEntryRepository{
+		entries: make(map[string]repository.TimeEntry),
+	}
+}
+
+func (r *timeEntryRepository) Create(entry repository.TimeEntry) error {
+	r.entries[entry.ID] = entry
+	return nil
+}
+
+func (r *timeEntryRepository) GetByID(id string) (*repository.TimeEntry, error) {
+	entry, exists := r.entries[id]
+	if !exists {
+		return nil, nil
+	}
+
+	return &entry, nil
+}
+
+func (r *timeEntryRepository) GetRunning(userID string) (*repository.TimeEntry, error) {
+	for _, entry := range r.entries {
+		if entry.UserID == userID && entry.StoppedAt == nil {
+			return &entry, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *timeEntryRepository) List(filter repository.TimeEntryFilter) ([]repository.TimeEntry, error) {
+	var taskIDs map[string]bool
+	if filter.TaskIDs != nil {
+		taskIDs = make(map[string]bool, len(filter.TaskIDs))
+		for _, id := range filter.TaskIDs {
+			taskIDs[id] = true
+		}
+	}
+
+	var entries []repository.TimeEntry
+	for _, entry := range r.entries {
+		if taskIDs != nil && !taskIDs[entry.TaskID] {
+			continue
+		}
+
+		if filter.UserID != "" && entry.UserID != filter.UserID {
+			continue
+		}
+
+		if filter.StartedAfter != nil && entry.StartedAt.Before(*filter.StartedAfter) {
+			continue
+		}
+
+		if filter.StartedBefore != nil && !entry.StartedAt.Before(*filter.StartedBefore) {
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].StartedAt.Equal(entries[j].StartedAt) {
+			return entries[i].StartedAt.Before(entries[j].StartedAt)
+		}
+		return entries[i].ID < entries[j].ID
+	})
+
+	return entries, nil
+}
+
+func (r *timeEntryRepository) Update(entry repository.TimeEntry) error {
+	if _, exists := r.entries[entry.ID]; !exists {
+		return nil
+	}
+
+	r.entries[entry.ID] = entry
+	return nil
+}
+
+func (r *timeEntryRepository) Delete(id string) error {
+	delete(r.entries, id)
+	return nil
+}
+
+func (r *timeEntryRepository) snapshot() func() {
+	entries := maps.Clone(r.entries)
+
+	return func() {
+		r.entries = entries
+	}
+}
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"sync"
+	"todo-api/internal/repository"
+)
+
+// snapshotter is implemented by repositories that can be rolled back; the
+// returned function undoes every change made since the snapshot.
+type snapshotter interface {
+	snapshot() (restore func())
+}
+
+// transactor runs transactions on the unguarded repositories of a store while
+// holding its lock, and rolls all of them back when a transaction fails.
+type transactor struct {
+	mu    *sync.Mutex
+	repos repository.Repository
+	// nested is set on the transactor of a running transaction, which
+	// already holds mu.
+	nested bool
+}
+
+func (t *transactor) InTransaction(fn func(repo *repository.Repository) error) error {
+	if !t.nested {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+
+	tx := t.repos
+	tx.Transactor = &transactor{mu: t.mu, repos: t.repos, nested: true}
+
+	repos := []interface{}{
+		tx.Task, tx.User, tx.Tag, tx.Project, tx.Comment, tx.Attachment,
+		tx.Workspace, tx.Transition, tx.Workflow, tx.History, tx.TimeEntry, tx.View, tx.Template,
+	}
+
+	var restores []func()
+	for _, repo := range repos {
+		if s, ok := repo.(snapshotter); ok {
+			restores = append(restores, s.snapshot())
+		}
+	}
+
+	if err := fn(&tx); err != nil {
+		for _, restore := range restores {
+			restore()
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package memory
+
+import (
+	"todo-api/internal/repository"
+)
+
+type transitionRepository struct {
+	transitions map[string][]repository.TaskTransition
+}
+
+func NewTransitionRepository() repository.TransitionRepository {
+	return &transitionRepository{
+		transitions: make(map[string][]repository.TaskTransition),
+	}
+}
+
+func (r *transitionRepository) Create(transition repository.TaskTransition) error {
+	r.transitions[transition.TaskID] = append(r.transitions[transition.TaskID], transition)
+	return nil
+}
+
+func (r *transitionRepository) GetByTaskID(taskID string) ([]repository.TaskTransition, error) {
+	transitions := make([]repository.TaskTransition, len(r.transitions[taskID]))
+	copy(transitions, r.transitions[taskID])
+	return transitions, nil
+}
+
+func (r *transitionRepository) snapshot() func() {
+	transitions := make(map[string][]repository.TaskTransition, len(r.transitions))
+	for taskID, taskTransitions := range r.transitions {
+		transitions[taskID] = taskTransitions
+	}
+
+	return func() {
+		r.transitions = transitions
+	}
+}
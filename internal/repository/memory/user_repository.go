@@ -1,16 +1,19 @@
 package memory
 
 import (
+	"maps"
 	"todo-api/internal/repository"
 )
 
 type userRepository struct {
-	users map[string]repository.User
+	users         map[string]repository.User
+	workspaceRepo repository.WorkspaceRepository
 }
 
-func NewUserRepository() repository.UserRepository {
+func NewUserRepository(workspaceRepo repository.WorkspaceRepository) repository.UserRepository {
 	return &userRepository{
-		users: make(map[string]repository.User),
+		users:         make(map[string]repository.User),
+		workspaceRepo: workspaceRepo,
 	}
 }
 
@@ -47,6 +50,22 @@ func (r *userRepository) GetAll() ([]repository.User, error) {
 	return users, nil
 }
 
+func (r *userRepository) GetByWorkspaceID(workspaceID string) ([]repository.User, error) {
+	members, err := r.workspaceRepo.GetMembers(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]repository.User, 0, len(members))
+	for _, member := range members {
+		if user, exists := r.users[member.UserID]; exists {
+			users = append(users, user)
+		}
+	}
+
+	return users, nil
+}
+
 func (r *userRepository) Update(user repository.User) error {
 	if _, exists := r.users[user.ID]; !exists {
 		return nil
@@ -60,3 +79,11 @@ func (r *userRepository) Delete(id string) error {
 	delete(r.users, id)
 	return nil
 }
+
+func (r *userRepository) snapshot() func() {
+	users := maps.Clone(r.users)
+
+	return func() {
+		r.users = users
+	}
+}
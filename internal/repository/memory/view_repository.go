@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"maps"
+	"sort"
+	"todo-api/internal/repository"
+)
+
+type viewRepository struct {
+	views  map[string]repository.View
+	shares map[string]map[string]bool
+}
+
+func NewViewRepository() repository.ViewRepository {
+	return &viewRepository{
+		views:  make(map[string]repository.View),
+		shares: make(map[string]map[string]bool),
+	}
+}
+
+func (r *viewRepository) Create(view repository.View) error {
+	r.views[view.ID] = view
+	return nil
+}
+
+func (r *viewRepository) GetByID(id string) (*repository.View, error) {
+	view, exists := r.views[id]
+	if !exists {
+		return nil, nil
+	}
+
+	return &view, nil
+}
+
+func (r *viewRepository) GetByUserID(userID, workspaceID string) ([]repository.View, error) {
+	var views []repository.View
+	for _, view := range r.views {
+		if view.UserID == userID && view.WorkspaceID == workspaceID {
+			views = append(views, view)
+		}
+	}
+
+	sortViews(views)
+	return views, nil
+}
+
+func (r *viewRepository) GetSharedWithUser(userID, workspaceID string) ([]repository.View, error) {
+	var views []repository.View
+	for viewID, users := range r.shares {
+		view, exists := r.views[viewID]
+		if exists && users[userID] && view.WorkspaceID == workspaceID {
+			views = append(views, view)
+		}
+	}
+
+	sortViews(views)
+	return views, nil
+}
+
+func (r *viewRepository) Update(view repository.View) error {
+	if _, exists := r.views[view.ID]; !exists {
+		return nil
+	}
+
+	r.views[view.ID] = view
+	return nil
+}
+
+func (r *viewRepository) Delete(id string) error {
+	delete(r.views, id)
+	delete(r.shares, id)
+	return nil
+}
+
+func (r *viewRepository) SetShares(viewID string, userIDs []string) error {
+	users := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		users[userID] = true
+	}
+
+	r.shares[viewID] = users
+	return nil
+}
+
+func (r *viewRepository) GetShares(viewID string) ([]string, error) {
+	var userIDs []string
+	for userID := range r.shares[viewID] {
+		userIDs = append(userIDs, userID)
+	}
+
+	sort.Strings(userIDs)
+	return userIDs, nil
+}
+
+func sortViews(views []repository.View) {
+	sort.Slice(views, func(i, j int) bool {
+		if views[i].Name != views[j].Name {
+			return views[i].Name < views[j].Name
+		}
+		return views[i].ID < views[j].ID
+	})
+}
+
+func (r *viewRepository) snapshot() func() {
+	views := maps.Clone(r.views)
+	shares := copyNested(r.shares)
+
+	return func() {
+		r.views, r.shares = views, shares
+	}
+}
@@ -0,0 +1,117 @@
+package memory
+
+import (
+	"sort"
+	"todo-api/internal/repository"
+)
+
+type workflowRepository struct {
+	statuses    map[string]repository.WorkflowStatus
+	transitions map[string]map[string]bool
+}
+
+// NewWorkflowRepository returns a repository seeded with the default workflow,
+// matching the rows created by the workflow migration.
+func NewWorkflowRepository() repository.WorkflowRepository {
+	r := &workflowRepository{
+		statuses:    make(map[string]repository.WorkflowStatus),
+		transitions: make(map[string]map[string]bool),
+	}
+
+	r.SaveWorkflow("", []repository.WorkflowStatus{
+		{ID: "default-new", Name: "New", Position: 0},
+		{ID: "default-in-progress", Name: "In progress", Position: 1},
+		{ID: "default-finished", Name: "Finished", Position: 2, Done: true},
+	}, []repository.WorkflowTransition{
+		{FromStatusID: "default-new", ToStatusID: "default-in-progress"},
+		{FromStatusID: "default-in-progress", ToStatusID: "default-new"},
+		{FromStatusID: "default-in-progress", ToStatusID: "default-finished"},
+		{FromStatusID: "default-finished", ToStatusID: "default-new"},
+		{FromStatusID: "default-finished", ToStatusID: "default-in-progress"},
+	})
+
+	return r
+}
+
+func (r *workflowRepository) GetStatuses(projectID string) ([]repository.WorkflowStatus, error) {
+	var statuses []repository.WorkflowStatus
+	for _, status := range r.statuses {
+		if status.ProjectID == projectID {
+			statuses = append(statuses, status)
+		}
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Position < statuses[j].Position
+	})
+
+	return statuses, nil
+}
+
+func (r *workflowRepository) GetStatus(id string) (*repository.WorkflowStatus, error) {
+	status, exists := r.statuses[id]
+	if !exists {
+		return nil, nil
+	}
+
+	return &status, nil
+}
+
+func (r *workflowRepository) GetTransitions(projectID string) ([]repository.WorkflowTransition, error) {
+	var transitions []repository.WorkflowTransition
+	for fromID, targets := range r.transitions {
+		if r.statuses[fromID].ProjectID != projectID {
+			continue
+		}
+
+		for toID := range targets {
+			transitions = append(transitions, repository.WorkflowTransition{FromStatusID: fromID, ToStatusID: toID})
+		}
+	}
+
+	return transitions, nil
+}
+
+func (r *workflowRepository) SaveWorkflow(projectID string, statuses []repository.WorkflowStatus, transitions []repository.WorkflowTransition) error {
+	for _, status := range statuses {
+		status.ProjectID = projectID
+		r.statuses[status.ID] = status
+	}
+
+	for fromID := range r.transitions {
+		if r.statuses[fromID].ProjectID == projectID {
+			delete(r.transitions, fromID)
+		}
+	}
+
+	for _, transition := range transitions {
+		if r.transitions[transition.FromStatusID] == nil {
+			r.transitions[transition.FromStatusID] = make(map[string]bool)
+		}
+		r.transitions[transition.FromStatusID][transition.ToStatusID] = true
+	}
+
+	return nil
+}
+
+func (r *workflowRepository) DeleteStatus(id string) error {
+	delete(r.statuses, id)
+	delete(r.transitions, id)
+	for _, targets := range r.transitions {
+		delete(targets, id)
+	}
+
+	return nil
+}
+
+func (r *workflowRepository) snapshot() func() {
+	statuses := make(map[string]repository.WorkflowStatus, len(r.statuses))
+	for id, status := range r.statuses {
+		statuses[id] = status
+	}
+	transitions := copyNested(r.transitions)
+
+	return func() {
+		r.statuses, r.transitions = statuses, transitions
+	}
+}
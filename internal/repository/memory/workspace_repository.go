@@ -0,0 +1,133 @@
+package memory
+
+import (
+	"maps"
+	"sort"
+	"todo-api/internal/repository"
+)
+
+type workspaceRepository struct {
+	workspaces  map[string]repository.Workspace
+	members     map[string]map[string]repository.WorkspaceMember
+	invitations map[string]repository.WorkspaceInvitation
+}
+
+func NewWorkspaceRepository() repository.WorkspaceRepository {
+	return &workspaceRepository{
+		workspaces:  make(map[string]repository.Workspace),
+		members:     make(map[string]map[string]repository.WorkspaceMember),
+		invitations: make(map[string]repository.WorkspaceInvitation),
+	}
+}
+
+func (r *workspaceRepository) Create(workspace repository.Workspace) error {
+	r.workspaces[workspace.ID] = workspace
+	return nil
+}
+
+func (r *workspaceRepository) GetByID(id string) (*repository.Workspace, error) {
+	workspace, exists := r.workspaces[id]
+	if !exists {
+		return nil, nil
+	}
+
+	return &workspace, nil
+}
+
+func (r *workspaceRepository) GetByUserID(userID string) ([]repository.Workspace, error) {
+	var workspaces []repository.Workspace
+	for workspaceID, members := range r.members {
+		if _, isMember := members[userID]; !isMember {
+			continue
+		}
+
+		if workspace, exists := r.workspaces[workspaceID]; exists {
+			workspaces = append(workspaces, workspace)
+		}
+	}
+
+	sort.Slice(workspaces, func(i, j int) bool {
+		return workspaces[i].CreatedAt.Before(workspaces[j].CreatedAt)
+	})
+
+	return workspaces, nil
+}
+
+func (r *workspaceRepository) SaveMember(member repository.WorkspaceMember) error {
+	if r.members[member.WorkspaceID] == nil {
+		r.members[member.WorkspaceID] = make(map[string]repository.WorkspaceMember)
+	}
+
+	if existing, exists := r.members[member.WorkspaceID][member.UserID]; exists {
+		member.CreatedAt = existing.CreatedAt
+	}
+
+	r.members[member.WorkspaceID][member.UserID] = member
+	return nil
+}
+
+func (r *workspaceRepository) GetMember(workspaceID, userID string) (*repository.WorkspaceMember, error) {
+	member, exists := r.members[workspaceID][userID]
+	if !exists {
+		return nil, nil
+	}
+
+	return &member, nil
+}
+
+func (r *workspaceRepository) GetMembers(workspaceID string) ([]repository.WorkspaceMember, error) {
+	var members []repository.WorkspaceMember
+	for _, member := range r.members[workspaceID] {
+		members = append(members, member)
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].CreatedAt.Before(members[j].CreatedAt)
+	})
+
+	return members, nil
+}
+
+func (r *workspaceRepository) SaveInvitation(invitation repository.WorkspaceInvitation) error {
+	r.invitations[invitation.ID] = invitation
+	return nil
+}
+
+func (r *workspaceRepository) GetInvitation(id string) (*repository.WorkspaceInvitation, error) {
+	invitation, exists := r.invitations[id]
+	if !exists {
+		return nil, nil
+	}
+
+	return &invitation, nil
+}
+
+func (r *workspaceRepository) GetInvitationsByUserID(userID string) ([]repository.WorkspaceInvitation, error) {
+	var invitations []repository.WorkspaceInvitation
+	for _, invitation := range r.invitations {
+		if invitation.UserID == userID {
+			invitations = append(invitations, invitation)
+		}
+	}
+
+	sort.Slice(invitations, func(i, j int) bool {
+		return invitations[i].CreatedAt.Before(invitations[j].CreatedAt)
+	})
+
+	return invitations, nil
+}
+
+func (r *workspaceRepository) DeleteInvitation(id string) error {
+	delete(r.invitations, id)
+	return nil
+}
+
+func (r *workspaceRepository) snapshot() func() {
+	workspaces := maps.Clone(r.workspaces)
+	members := copyNested(r.members)
+	invitations := maps.Clone(r.invitations)
+
+	return func() {
+		r.workspaces, r.members, r.invitations = workspaces, members, invitations
+	}
+}
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"database/sql"
+	"todo-api/internal/repository"
+)
+
+type attachmentRepository struct {
+	db querier
+}
+
+func NewAttachmentRepository(db *sql.DB) repository.AttachmentRepository {
+	return &attachmentRepository{db: db}
+}
+
+func (r *attachmentRepository) Create(attachment repository.Attachment) error {
+	query := `
+		INSERT INTO attachments (id, task_id, user_id, filename, content_type, size, checksum, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.Exec(query,
+		attachment.ID,
+		attachment.TaskID,
+		attachment.UserID,
+		attachment.Filename,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.Checksum,
+		attachment.CreatedAt,
+	)
+
+	return err
+}
+
+func (r *attachmentRepository) GetByID(id string) (*repository.Attachment, error) {
+	query := `
+		SELECT id, task_id, user_id, filename, content_type, size, checksum, created_at
+		FROM attachments
+		WHERE id = $1
+	`
+
+	var attachment repository.Attachment
+	err := r.db.QueryRow(query, id).Scan(
+		&attachment.ID,
+		&attachment.TaskID,
+		&attachment.UserID,
+		&attachment.Filename,
+		&attachment.ContentType,
+		&attachment.Size,
+		&attachment.Checksum,
+		&attachment.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+func (r *attachmentRepository) GetByTaskID(taskID string) ([]repository.Attachment, error) {
+	query := `
+		SELECT id, task_id, user_id, filename, content_type, size, checksum, created_at
+		FROM attachments
+		WHERE task_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.db.Query(query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attachments []repository.Attachment
+	for rows.Next() {
+		var attachment repository.Attachment
+		if err := rows.Scan(
+			&attachment.ID,
+			&attachment.TaskID,
+			&attachment.UserID,
+			&attachment.Filename,
+			&attachment.ContentType,
+			&attachment.Size,
+			&attachment.Checksum,
+			&attachment.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, rows.Err()
+}
+
+func (r *attachmentRepository) CountByChecksum(checksum string) (int, error) {
+	query := `SELECT COUNT(*) FROM attachments WHERE checksum = $1`
+
+	var count int
+	err := r.db.QueryRow(query, checksum).Scan(&count)
+	return count, err
+}
+
+func (r *attachmentRepository) Delete(id string) error {
+	query := `DELETE FROM attachments WHERE id = $1`
+	_, err := r.db.Exec(query, id)
+	return err
+}
@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"database/sql"
+	"todo-api/internal/repository"
+)
+
+type commentRepository struct {
+	db querier
+}
+
+func NewCommentRepository(db *sql.DB) repository.CommentRepository {
+	return &commentRepository{db: db}
+}
+
+func (r *commentRepository) Create(comment repository.Comment) error {
+	query := `
+		INSERT INTO comments (id, task_id, user_id, body, edited, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.Exec(query,
+		comment.ID,
+		comment.TaskID,
+		comment.UserID,
+		comment.Body,
+		comment.Edited,
+		comment.CreatedAt,
+		comment.UpdatedAt,
+	)
+
+	return err
+}
+
+func (r *commentRepository) GetByID(id string) (*repository.Comment, error) {
+	query := `
+		SELECT id, task_id, user_id, body, edited, created_at, updated_at
+		FROM comments
+		WHERE id = $1
+	`
+
+	var comment repository.Comment
+	err := r.db.QueryRow(query, id).Scan(
+		&comment.ID,
+		&comment.TaskID,
+		&comment.UserID,
+		&comment.Body,
+		&comment.Edited,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+func (r *commentRepository) GetByTaskID(taskID string, limit, offset int) ([]repository.Comment, error) {
+	query := `
+		SELECT id, task_id, user_id, body, edited, created_at, updated_at
+		FROM comments
+		WHERE task_id = $1
+		ORDER BY created_at, id
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(query, taskID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []repository.Comment
+	for rows.Next() {
+		var comment repository.Comment
+		if err := rows.Scan(
+			&comment.ID,
+			&comment.TaskID,
+			&comment.UserID,
+			&comment.Body,
+			&comment.Edited,
+			&comment.CreatedAt,
+			&comment.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+
+	return comments, rows.Err()
+}
+
+func (r *commentRepository) CountByTaskID(taskID string) (int, error) {
+	query := `SELECT COUNT(*) FROM comments WHERE task_id = $1`
+
+	var count int
+	err := r.db.QueryRow(query, taskID).Scan(&count)
+	return count, err
+}
+
+func (r *commentRepository) Update(comment repository.Comment) error {
+	query := `
+		UPDATE comments
+		SET body = $2, edited = $3, updated_at = $4
+		WHERE id = $1
+	`
+
+	_, err := r.db.Exec(query,
+		comment.ID,
+		comment.Body,
+		comment.Edited,
+		comment.UpdatedAt,
+	)
+
+	return err
+}
+
+func (r *commentRepository) Delete(id string) error {
+	query := `DELETE FROM comments WHERE id = $1`
+	_, err := r.db.Exec(query, id)
+	return err
+}
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"todo-api/internal/repository"
+)
+
+type historyRepository struct {
+	db querier
+}
+
+func NewHistoryRepository(db *sql.DB) repository.HistoryRepository {
+	return &historyRepository{db: db}
+}
+
+func (r *historyRepository) Create(entry repository.TaskHistoryEntry) error {
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO task_history (id, task_id, user_id, action, changes, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err = r.db.Exec(query,
+		entry.ID,
+		entry.TaskID,
+		nullString(entry.UserID),
+		entry.Action,
+		changes,
+		entry.CreatedAt,
+	)
+
+	return err
+}
+
+func (r *historyRepository) GetByTaskID(taskID string) ([]repository.TaskHistoryEntry, error) {
+	query := `
+		SELECT id, task_id, user_id, action, changes, created_at
+		FROM task_history
+		WHERE task_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.db.Query(query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []repository.TaskHistoryEntry
+	for rows.Next() {
+		var entry repository.TaskHistoryEntry
+		var userID sql.NullString
+		var changes []byte
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.TaskID,
+			&userID,
+			&entry.Action,
+			&changes,
+			&entry.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(changes, &entry.Changes); err != nil {
+			return nil, err
+		}
+		entry.UserID = userID.String
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
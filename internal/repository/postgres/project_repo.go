@@ -0,0 +1,221 @@
+package postgres
+
+import (
+	"database/sql"
+	"todo-api/internal/repository"
+)
+
+type projectRepository struct {
+	db querier
+}
+
+func NewProjectRepository(db *sql.DB) repository.ProjectRepository {
+	return &projectRepository{db: db}
+}
+
+func (r *projectRepository) Create(project repository.Project) error {
+	query := `
+		INSERT INTO projects (id, name, description, user_id, workspace_id, estimate_unit, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.Exec(query,
+		project.ID,
+		project.Name,
+		project.Description,
+		project.UserID,
+		project.WorkspaceID,
+		project.EstimateUnit,
+		project.CreatedAt,
+	)
+
+	return err
+}
+
+func (r *projectRepository) GetByID(id string) (*repository.Project, error) {
+	query := `
+		SELECT id, name, description, user_id, workspace_id, estimate_unit, created_at
+		FROM projects
+		WHERE id = $1
+	`
+
+	var project repository.Project
+	err := r.db.QueryRow(query, id).Scan(
+		&project.ID,
+		&project.Name,
+		&project.Description,
+		&project.UserID,
+		&project.WorkspaceID,
+		&project.EstimateUnit,
+		&project.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+func (r *projectRepository) GetByUserID(userID, workspaceID string) ([]repository.Project, error) {
+	query := `
+		SELECT id, name, description, user_id, workspace_id, estimate_unit, created_at
+		FROM projects
+		WHERE user_id = $1 AND workspace_id = $2
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(query, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanProjects(rows)
+}
+
+func (r *projectRepository) GetSharedWithUser(userID, workspaceID string) ([]repository.Project, error) {
+	query := `
+		SELECT p.id, p.name, p.description, p.user_id, p.workspace_id, p.estimate_unit, p.created_at
+		FROM projects p
+		JOIN project_members pm ON pm.project_id = p.id
+		WHERE pm.user_id = $1 AND p.workspace_id = $2
+		ORDER BY p.created_at
+	`
+
+	rows, err := r.db.Query(query, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanProjects(rows)
+}
+
+func scanProjects(rows *sql.Rows) ([]repository.Project, error) {
+	defer rows.Close()
+
+	var projects []repository.Project
+	for rows.Next() {
+		var project repository.Project
+		if err := rows.Scan(
+			&project.ID,
+			&project.Name,
+			&project.Description,
+			&project.UserID,
+			&project.WorkspaceID,
+			&project.EstimateUnit,
+			&project.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+
+	return projects, rows.Err()
+}
+
+func (r *projectRepository) Update(project repository.Project) error {
+	query := `
+		UPDATE projects
+		SET name = $2, description = $3, estimate_unit = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+
+	_, err := r.db.Exec(query,
+		project.ID,
+		project.Name,
+		project.Description,
+		project.EstimateUnit,
+	)
+
+	return err
+}
+
+func (r *projectRepository) Delete(id string) error {
+	query := `DELETE FROM projects WHERE id = $1`
+	_, err := r.db.Exec(query, id)
+	return err
+}
+
+func (r *projectRepository) SaveMember(member repository.ProjectMember) error {
+	query := `
+		INSERT INTO project_members (project_id, user_id, role, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (project_id, user_id) DO UPDATE SET role = EXCLUDED.role
+	`
+
+	_, err := r.db.Exec(query,
+		member.ProjectID,
+		member.UserID,
+		member.Role,
+		member.CreatedAt,
+	)
+
+	return err
+}
+
+func (r *projectRepository) GetMember(projectID, userID string) (*repository.ProjectMember, error) {
+	query := `
+		SELECT project_id, user_id, role, created_at
+		FROM project_members
+		WHERE project_id = $1 AND user_id = $2
+	`
+
+	var member repository.ProjectMember
+	err := r.db.QueryRow(query, projectID, userID).Scan(
+		&member.ProjectID,
+		&member.UserID,
+		&member.Role,
+		&member.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (r *projectRepository) GetMembers(projectID string) ([]repository.ProjectMember, error) {
+	query := `
+		SELECT project_id, user_id, role, created_at
+		FROM project_members
+		WHERE project_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(query, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []repository.ProjectMember
+	for rows.Next() {
+		var member repository.ProjectMember
+		if err := rows.Scan(
+			&member.ProjectID,
+			&member.UserID,
+			&member.Role,
+			&member.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	return members, rows.Err()
+}
+
+func (r *projectRepository) RemoveMember(projectID, userID string) error {
+	query := `DELETE FROM project_members WHERE project_id = $1 AND user_id = $2`
+	_, err := r.db.Exec(query, projectID, userID)
+	return err
+}
@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"database/sql"
+	"todo-api/internal/repository"
+)
+
+type tagRepository struct {
+	db querier
+}
+
+func NewTagRepository(db *sql.DB) repository.TagRepository {
+	return &tagRepository{db: db}
+}
+
+func (r *tagRepository) Create(tag repository.Tag) error {
+	query := `
+		INSERT INTO tags (id, name, color, user_id, workspace_id)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := r.db.Exec(query,
+		tag.ID,
+		tag.Name,
+		tag.Color,
+		tag.UserID,
+		tag.WorkspaceID,
+	)
+
+	return err
+}
+
+func (r *tagRepository) GetByID(id string) (*repository.Tag, error) {
+	query := `
+		SELECT id, name, color, user_id, workspace_id
+		FROM tags
+		WHERE id = $1
+	`
+
+	var tag repository.Tag
+	err := r.db.QueryRow(query, id).Scan(
+		&tag.ID,
+		&tag.Name,
+		&tag.Color,
+		&tag.UserID,
+		&tag.WorkspaceID,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+func (r *tagRepository) GetByUserID(userID, workspaceID string) ([]repository.Tag, error) {
+	query := `
+		SELECT id, name, color, user_id, workspace_id
+		FROM tags
+		WHERE user_id = $1 AND workspace_id = $2
+		ORDER BY name
+	`
+
+	rows, err := r.db.Query(query, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanTags(rows)
+}
+
+func (r *tagRepository) Update(tag repository.Tag) error {
+	query := `
+		UPDATE tags
+		SET name = $2, color = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+
+	_, err := r.db.Exec(query,
+		tag.ID,
+		tag.Name,
+		tag.Color,
+	)
+
+	return err
+}
+
+func (r *tagRepository) Delete(id string) error {
+	query := `DELETE FROM tags WHERE id = $1`
+	_, err := r.db.Exec(query, id)
+	return err
+}
+
+func scanTags(rows *sql.Rows) ([]repository.Tag, error) {
+	defer rows.Close()
+
+	var tags []repository.Tag
+	for rows.Next() {
+		var tag repository.Tag
+		if err := rows.Scan(
+			&tag.ID,
+			&tag.Name,
+			&tag.Color,
+			&tag.UserID,
+			&tag.WorkspaceID,
+		); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
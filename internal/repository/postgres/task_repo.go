@@ -2,29 +2,152 @@ package postgres
 
 import (
 	"database/sql"
+	"html"
+	"strconv"
+	"strings"
+	"time"
 	"todo-api/internal/repository"
+
+	"github.com/lib/pq"
 )
 
+const taskColumns = `id, title, description, status_id,
+	(SELECT name FROM workflow_statuses WHERE id = tasks.status_id),
+	(SELECT position FROM workflow_statuses WHERE id = tasks.status_id),
+	(SELECT is_done FROM workflow_statuses WHERE id = tasks.status_id),
+	board_position, user_id, due_at, due_has_time, due_timezone, priority, created_at, parent_id, recurrence, project_id, assignee_id, workspace_id,
+	ARRAY(
+		SELECT tg.name FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id
+		WHERE tt.task_id = tasks.id ORDER BY tg.name
+	),
+	ARRAY(
+		SELECT td.depends_on_id FROM task_dependencies td JOIN tasks d ON d.id = td.depends_on_id
+		WHERE td.task_id = tasks.id AND d.deleted_at IS NULL ORDER BY td.depends_on_id
+	),
+	ARRAY(
+		SELECT td.task_id FROM task_dependencies td JOIN tasks d ON d.id = td.task_id
+		WHERE td.depends_on_id = tasks.id AND d.deleted_at IS NULL ORDER BY td.task_id
+	),
+	archived_at, deleted_at, estimate, completed_at`
+
 type taskRepository struct {
-	db *sql.DB
+	db querier
 }
 
 func NewTaskRepository(db *sql.DB) repository.TaskRepository {
 	return &taskRepository{db: db}
 }
 
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTask(row rowScanner) (repository.Task, error) {
+	var task repository.Task
+	var dueAt, archivedAt, deletedAt, completedAt sql.NullTime
+	var estimate sql.NullFloat64
+	var parentID, projectID, assigneeID sql.NullString
+	err := row.Scan(
+		&task.ID,
+		&task.Title,
+		&task.Description,
+		&task.StatusID,
+		&task.Status,
+		&task.StatusPosition,
+		&task.Done,
+		&task.BoardPosition,
+		&task.UserID,
+		&dueAt,
+		&task.DueHasTime,
+		&task.DueTimezone,
+		&task.Priority,
+		&task.CreatedAt,
+		&parentID,
+		&task.Recurrence,
+		&projectID,
+		&assigneeID,
+		&task.WorkspaceID,
+		pq.Array(&task.Tags),
+		pq.Array(&task.BlockedBy),
+		pq.Array(&task.Blocks),
+		&archivedAt,
+		&deletedAt,
+		&estimate,
+		&completedAt,
+	)
+	if err != nil {
+		return task, err
+	}
+
+	task.ParentID = parentID.String
+	task.ProjectID = projectID.String
+	task.AssigneeID = assigneeID.String
+
+	if dueAt.Valid {
+		task.DueAt = &dueAt.Time
+	}
+
+	if archivedAt.Valid {
+		task.ArchivedAt = &archivedAt.Time
+	}
+
+	if deletedAt.Valid {
+		task.DeletedAt = &deletedAt.Time
+	}
+
+	if estimate.Valid {
+		task.Estimate = &estimate.Float64
+	}
+
+	if completedAt.Valid {
+		task.CompletedAt = &completedAt.Time
+	}
+
+	return task, nil
+}
+
+func scanTasks(rows *sql.Rows) ([]repository.Task, error) {
+	defer rows.Close()
+
+	var tasks []repository.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, rows.Err()
+}
+
 func (r *taskRepository) Create(task repository.Task) error {
 	query := `
-		INSERT INTO tasks (id, title, description, status, user_id)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO tasks (id, title, description, status_id, user_id, due_at, due_has_time, due_timezone, priority, created_at,
+			parent_id, recurrence, project_id, assignee_id, workspace_id, board_position, archived_at, estimate, completed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
 	`
 
 	_, err := r.db.Exec(query,
 		task.ID,
 		task.Title,
 		task.Description,
-		task.Status,
+		task.StatusID,
 		task.UserID,
+		task.DueAt,
+		task.DueHasTime,
+		task.DueTimezone,
+		task.Priority,
+		task.CreatedAt,
+		nullString(task.ParentID),
+		task.Recurrence,
+		nullString(task.ProjectID),
+		nullString(task.AssigneeID),
+		task.WorkspaceID,
+		task.BoardPosition,
+		task.ArchivedAt,
+		task.Estimate,
+		task.CompletedAt,
 	)
 
 	return err
@@ -32,19 +155,12 @@ func (r *taskRepository) Create(task repository.Task) error {
 
 func (r *taskRepository) GetByID(id string) (*repository.Task, error) {
 	query := `
-		SELECT id, title, description, status, user_id
+		SELECT ` + taskColumns + `
 		FROM tasks
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
-	var task repository.Task
-	err := r.db.QueryRow(query, id).Scan(
-		&task.ID,
-		&task.Title,
-		&task.Description,
-		&task.Status,
-		&task.UserID,
-	)
+	task, err := scanTask(r.db.QueryRow(query, id))
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -57,42 +173,221 @@ func (r *taskRepository) GetByID(id string) (*repository.Task, error) {
 	return &task, nil
 }
 
-func (r *taskRepository) GetAll() ([]repository.Task, error) {
+func (r *taskRepository) List(filter repository.TaskFilter) ([]repository.Task, error) {
+	var qb queryBuilder
+	filterTasks(&qb, filter)
+
+	if filter.After != nil {
+		afterTask(&qb, filter.Sort, *filter.After)
+	}
+
+	limit := ""
+	if filter.Limit > 0 {
+		qb.args = append(qb.args, filter.Limit)
+		limit = "LIMIT $" + strconv.Itoa(len(qb.args))
+	}
+
 	query := `
-		SELECT id, title, description, status, user_id
+		SELECT ` + taskColumns + `
 		FROM tasks
-		ORDER BY created_at DESC
+		` + qb.whereClause() + `
+		ORDER BY ` + orderByClause(filter.Sort) + `
+		` + limit + `
+	`
+
+	rows, err := r.db.Query(query, qb.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanTasks(rows)
+}
+
+// filterTasks adds the conditions selecting the tasks that match filter.
+func filterTasks(qb *queryBuilder, filter repository.TaskFilter) {
+	if filter.Trashed {
+		qb.where("deleted_at IS NOT NULL")
+	} else {
+		qb.where("deleted_at IS NULL")
+	}
+
+	if filter.DeletedBefore != nil {
+		qb.where("deleted_at < ?", *filter.DeletedBefore)
+	}
+
+	if filter.WorkspaceID != "" {
+		qb.where("workspace_id = ?", filter.WorkspaceID)
+	}
+
+	if filter.ProjectID != nil {
+		if *filter.ProjectID == "" {
+			qb.where("project_id IS NULL")
+		} else {
+			qb.where("project_id = ?", *filter.ProjectID)
+		}
+	}
+
+	if filter.ParentID != nil {
+		qb.where("parent_id = ?", *filter.ParentID)
+	}
+
+	if filter.StatusID != "" {
+		qb.where("status_id = ?", filter.StatusID)
+	}
+
+	if filter.Status != "" {
+		qb.where("LOWER((SELECT name FROM workflow_statuses WHERE id = tasks.status_id)) = LOWER(?)", filter.Status)
+	}
+
+	if filter.UserID != "" {
+		qb.where("user_id = ?", filter.UserID)
+	}
+
+	if filter.VisibleTo != "" {
+		qb.where("(user_id = ? OR assignee_id = ? OR project_id = ANY(?))",
+			filter.VisibleTo, filter.VisibleTo, pq.Array(filter.VisibleProjectIDs))
+	}
+
+	if filter.AssigneeID != nil {
+		if *filter.AssigneeID == "" {
+			qb.where("assignee_id IS NULL")
+		} else {
+			qb.where("assignee_id = ?", *filter.AssigneeID)
+		}
+	}
+
+	if filter.DueBefore != nil {
+		qb.where("due_at < ?", *filter.DueBefore)
+	}
+
+	if filter.DueAfter != nil {
+		qb.where("due_at >= ?", *filter.DueAfter)
+	}
+
+	if filter.CreatedBefore != nil {
+		qb.where("created_at < ?", *filter.CreatedBefore)
+	}
+
+	if filter.CreatedAfter != nil {
+		qb.where("created_at >= ?", *filter.CreatedAfter)
+	}
+
+	if filter.Text != "" {
+		pattern := "%" + likeEscaper.Replace(filter.Text) + "%"
+		qb.where("(title ILIKE ? OR description ILIKE ?)", pattern, pattern)
+	}
+
+	if filter.ExcludeDone {
+		qb.where("NOT (SELECT is_done FROM workflow_statuses WHERE id = tasks.status_id)")
+	}
+
+	if filter.OnlyDone {
+		qb.where("(SELECT is_done FROM workflow_statuses WHERE id = tasks.status_id)")
+	}
+
+	if filter.CompletedBefore != nil {
+		qb.where("completed_at < ?", *filter.CompletedBefore)
+	}
+
+	if filter.CompletedAfter != nil {
+		qb.where("completed_at >= ?", *filter.CompletedAfter)
+	}
+
+	if filter.ExcludeArchived {
+		qb.where("archived_at IS NULL")
+	}
+
+	if len(filter.Tags) > 0 {
+		tagged := `
+			SELECT COUNT(DISTINCT tg.name) FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id
+			WHERE tt.task_id = tasks.id AND tg.workspace_id = tasks.workspace_id AND tg.user_id = ? AND tg.name = ANY(?)
+		`
+		if filter.MatchAllTags {
+			qb.where("("+tagged+") = ?", filter.TagUserID, pq.Array(filter.Tags), len(uniqueStrings(filter.Tags)))
+		} else {
+			qb.where("("+tagged+") > 0", filter.TagUserID, pq.Array(filter.Tags))
+		}
+	}
+}
+
+// Search matches the tasks' search_vector, which weighs titles above
+// descriptions, and highlights the matches in a snippet.
+// ts_headline marks the matches with private-use characters, so the text can
+// be HTML-escaped before they are turned into <mark> tags.
+func (r *taskRepository) Search(filter repository.TaskFilter, search repository.TaskSearch) ([]repository.TaskSearchHit, error) {
+	var qb queryBuilder
+	qb.where("search_vector @@ to_tsquery('english', ?)", tsQuery(search.Terms))
+	filterTasks(&qb, filter)
+	qb.args = append(qb.args, search.Limit)
+
+	query := `
+		SELECT ` + taskColumns + `,
+			ts_rank(search_vector, to_tsquery('english', $1)) AS rank,
+			ts_headline('english', title || ' ' || description, to_tsquery('english', $1),
+				'StartSel="` + snippetStart + `", StopSel="` + snippetStop + `", MaxWords=20, MinWords=5')
+		FROM tasks
+		` + qb.whereClause() + `
+		ORDER BY rank DESC, created_at DESC, id
+		LIMIT $` + strconv.Itoa(len(qb.args)) + `
 	`
 
-	rows, err := r.db.Query(query)
+	rows, err := r.db.Query(query, qb.args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var tasks []repository.Task
+	var hits []repository.TaskSearchHit
 	for rows.Next() {
-		var task repository.Task
-		if err := rows.Scan(
-			&task.ID,
-			&task.Title,
-			&task.Description,
-			&task.Status,
-			&task.UserID,
-		); err != nil {
+		var hit repository.TaskSearchHit
+		task, err := scanTask(extraColumns{row: rows, dest: []interface{}{&hit.Rank, &hit.Snippet}})
+		if err != nil {
 			return nil, err
 		}
-		tasks = append(tasks, task)
+		hit.Task = task
+		hit.Snippet = snippetMarker.Replace(html.EscapeString(hit.Snippet))
+		hits = append(hits, hit)
+	}
+
+	return hits, rows.Err()
+}
+
+// tsQuery turns search terms into a tsquery: the words of a phrase must
+// follow each other and every term has to match.
+func tsQuery(terms []repository.SearchTerm) string {
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		phrase := strings.Join(term.Words, " <-> ")
+		if term.Prefix {
+			phrase += ":*"
+		}
+		parts[i] = "(" + phrase + ")"
 	}
+	return strings.Join(parts, " & ")
+}
+
+const (
+	snippetStart = "\ue000"
+	snippetStop  = "\ue001"
+)
+
+var snippetMarker = strings.NewReplacer(snippetStart, "<mark>", snippetStop, "</mark>")
+
+// extraColumns scans the columns following the task columns of a row.
+type extraColumns struct {
+	row  rowScanner
+	dest []interface{}
+}
 
-	return tasks, nil
+func (e extraColumns) Scan(dest ...interface{}) error {
+	return e.row.Scan(append(dest, e.dest...)...)
 }
 
 func (r *taskRepository) GetByUserID(userID string) ([]repository.Task, error) {
 	query := `
-		SELECT id, title, description, status, user_id
+		SELECT ` + taskColumns + `
 		FROM tasks
-		WHERE user_id = $1
+		WHERE user_id = $1 AND deleted_at IS NULL
 		ORDER BY created_at DESC
 	`
 
@@ -100,30 +395,33 @@ func (r *taskRepository) GetByUserID(userID string) ([]repository.Task, error) {
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	var tasks []repository.Task
-	for rows.Next() {
-		var task repository.Task
-		if err := rows.Scan(
-			&task.ID,
-			&task.Title,
-			&task.Description,
-			&task.Status,
-			&task.UserID,
-		); err != nil {
-			return nil, err
-		}
-		tasks = append(tasks, task)
+	return scanTasks(rows)
+}
+
+func (r *taskRepository) GetChildren(parentID string) ([]repository.Task, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE parent_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.db.Query(query, parentID)
+	if err != nil {
+		return nil, err
 	}
 
-	return tasks, nil
+	return scanTasks(rows)
 }
 
 func (r *taskRepository) Update(task repository.Task) error {
 	query := `
 		UPDATE tasks
-		SET title = $2, description = $3, status = $4, updated_at = CURRENT_TIMESTAMP
+		SET title = $2, description = $3, status_id = $4, due_at = $5, due_has_time = $6, due_timezone = $7,
+			priority = $8, parent_id = $9, recurrence = $10, project_id = $11,
+			assignee_id = $12, board_position = $13, archived_at = $14, estimate = $15, completed_at = $16,
+			updated_at = CURRENT_TIMESTAMP
 		WHERE id = $1
 	`
 
@@ -131,7 +429,19 @@ func (r *taskRepository) Update(task repository.Task) error {
 		task.ID,
 		task.Title,
 		task.Description,
-		task.Status,
+		task.StatusID,
+		task.DueAt,
+		task.DueHasTime,
+		task.DueTimezone,
+		task.Priority,
+		nullString(task.ParentID),
+		task.Recurrence,
+		nullString(task.ProjectID),
+		nullString(task.AssigneeID),
+		task.BoardPosition,
+		task.ArchivedAt,
+		task.Estimate,
+		task.CompletedAt,
 	)
 
 	return err
@@ -142,3 +452,295 @@ func (r *taskRepository) Delete(id string) error {
 	_, err := r.db.Exec(query, id)
 	return err
 }
+
+func (r *taskRepository) AddTag(taskID, tagID string) error {
+	query := `
+		INSERT INTO task_tags (task_id, tag_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+	_, err := r.db.Exec(query, taskID, tagID)
+	return err
+}
+
+func (r *taskRepository) RemoveTag(taskID, tagID string) error {
+	query := `DELETE FROM task_tags WHERE task_id = $1 AND tag_id = $2`
+	_, err := r.db.Exec(query, taskID, tagID)
+	return err
+}
+
+func (r *taskRepository) GetTags(taskID string) ([]repository.Tag, error) {
+	query := `
+		SELECT tg.id, tg.name, tg.color, tg.user_id, tg.workspace_id
+		FROM tags tg
+		JOIN task_tags tt ON tt.tag_id = tg.id
+		WHERE tt.task_id = $1
+		ORDER BY tg.name
+	`
+
+	rows, err := r.db.Query(query, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanTags(rows)
+}
+
+func (r *taskRepository) AddDependency(taskID, dependsOnID string) error {
+	query := `
+		INSERT INTO task_dependencies (task_id, depends_on_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+	_, err := r.db.Exec(query, taskID, dependsOnID)
+	return err
+}
+
+func (r *taskRepository) RemoveDependency(taskID, dependsOnID string) error {
+	query := `DELETE FROM task_dependencies WHERE task_id = $1 AND depends_on_id = $2`
+	_, err := r.db.Exec(query, taskID, dependsOnID)
+	return err
+}
+
+func (r *taskRepository) GetDependencies(taskID string) ([]repository.Task, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE id IN (SELECT depends_on_id FROM task_dependencies WHERE task_id = $1) AND deleted_at IS NULL
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.db.Query(query, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanTasks(rows)
+}
+
+func (r *taskRepository) GetDependencyIDs(taskID string) ([]string, error) {
+	query := `SELECT depends_on_id FROM task_dependencies WHERE task_id = $1 ORDER BY depends_on_id`
+
+	rows, err := r.db.Query(query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+func (r *taskRepository) MoveProjectTasks(fromProjectID, toProjectID string) error {
+	query := `UPDATE tasks SET project_id = $2, updated_at = CURRENT_TIMESTAMP WHERE project_id = $1`
+	_, err := r.db.Exec(query, fromProjectID, nullString(toProjectID))
+	return err
+}
+
+func (r *taskRepository) DeleteByProjectID(projectID string) error {
+	query := `DELETE FROM tasks WHERE project_id = $1`
+	_, err := r.db.Exec(query, projectID)
+	return err
+}
+
+func (r *taskRepository) ReplaceStatus(projectID, fromStatusID, toStatusID string) error {
+	query := `
+		UPDATE tasks t SET status_id = s.id,
+			completed_at = CASE WHEN s.is_done THEN COALESCE(t.completed_at, CURRENT_TIMESTAMP) END,
+			updated_at = CURRENT_TIMESTAMP
+		FROM workflow_statuses s
+		WHERE s.id = $3 AND t.project_id = $1 AND t.status_id = $2
+	`
+	_, err := r.db.Exec(query, projectID, fromStatusID, toStatusID)
+	return err
+}
+
+func (r *taskRepository) SoftDelete(id string, deletedAt time.Time) error {
+	query := `
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM tasks WHERE id = $1 AND deleted_at IS NULL
+			UNION ALL
+			SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at IS NULL
+		)
+		UPDATE tasks SET deleted_at = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (SELECT id FROM subtree)
+	`
+	_, err := r.db.Exec(query, id, deletedAt)
+	return err
+}
+
+func (r *taskRepository) Restore(id string) error {
+	query := `
+		WITH RECURSIVE subtree AS (
+			SELECT id, deleted_at FROM tasks WHERE id = $1 AND deleted_at IS NOT NULL
+			UNION ALL
+			SELECT t.id, t.deleted_at FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at = s.deleted_at
+		)
+		UPDATE tasks SET deleted_at = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (SELECT id FROM subtree)
+	`
+	_, err := r.db.Exec(query, id)
+	return err
+}
+
+func (r *taskRepository) GetTrashed(id string) (*repository.Task, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`
+
+	task, err := scanTask(r.db.QueryRow(query, id))
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &task, nil
+}
+
+var sortColumns = map[string]string{
+	repository.SortByCreated:   "created_at",
+	repository.SortByDue:       "due_at",
+	repository.SortByPriority:  "priority",
+	repository.SortByTitle:     "title",
+	repository.SortByStatus:    "(SELECT position FROM workflow_statuses WHERE id = tasks.status_id)",
+	repository.SortByPosition:  "board_position",
+	repository.SortByDeleted:   "deleted_at",
+	repository.SortByCompleted: "completed_at",
+}
+
+// sortValue is the value a task has in one column of the listing order.
+type sortValue struct {
+	column string
+	value  interface{}
+	desc   bool
+}
+
+func sortValues(keys []repository.SortKey, task repository.Task) []sortValue {
+	var values []sortValue
+	for _, key := range keys {
+		column, ok := sortColumns[key.Field]
+		if !ok {
+			continue
+		}
+
+		var value interface{}
+		switch key.Field {
+		case repository.SortByCreated:
+			value = task.CreatedAt
+		case repository.SortByDue:
+			if task.DueAt != nil {
+				value = *task.DueAt
+			}
+		case repository.SortByPriority:
+			value = task.Priority
+		case repository.SortByTitle:
+			value = task.Title
+		case repository.SortByStatus:
+			value = task.StatusPosition
+		case repository.SortByPosition:
+			value = task.BoardPosition
+		case repository.SortByDeleted:
+			if task.DeletedAt != nil {
+				value = *task.DeletedAt
+			}
+		case repository.SortByCompleted:
+			if task.CompletedAt != nil {
+				value = *task.CompletedAt
+			}
+		}
+		values = append(values, sortValue{column: column, value: value, desc: key.Desc})
+	}
+
+	return append(values,
+		sortValue{column: "created_at", value: task.CreatedAt, desc: true},
+		sortValue{column: "id", value: task.ID, desc: true},
+	)
+}
+
+// afterTask restricts a listing to the tasks that orderByClause puts behind
+// after, comparing the sort columns one by one. NULLs sort last, so nothing
+// but other NULLs follows a NULL.
+func afterTask(qb *queryBuilder, keys []repository.SortKey, after repository.Task) {
+	values := sortValues(keys, after)
+
+	var alternatives []string
+	var args []interface{}
+	for i, current := range values {
+		if current.value == nil {
+			continue
+		}
+
+		var conditions []string
+		for _, previous := range values[:i] {
+			if previous.value == nil {
+				conditions = append(conditions, previous.column+" IS NULL")
+				continue
+			}
+			conditions = append(conditions, previous.column+" = ?")
+			args = append(args, previous.value)
+		}
+
+		op := ">"
+		if current.desc {
+			op = "<"
+		}
+		conditions = append(conditions, "("+current.column+" "+op+" ? OR "+current.column+" IS NULL)")
+		args = append(args, current.value)
+
+		alternatives = append(alternatives, "("+strings.Join(conditions, " AND ")+")")
+	}
+
+	if len(alternatives) == 0 {
+		qb.where("FALSE")
+		return
+	}
+
+	qb.where("("+strings.Join(alternatives, " OR ")+")", args...)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func orderByClause(keys []repository.SortKey) string {
+	var parts []string
+	for _, key := range keys {
+		column, ok := sortColumns[key.Field]
+		if !ok {
+			continue
+		}
+
+		direction := "ASC"
+		if key.Desc {
+			direction = "DESC"
+		}
+		parts = append(parts, column+" "+direction+" NULLS LAST")
+	}
+
+	parts = append(parts, "created_at DESC", "id DESC")
+	return strings.Join(parts, ", ")
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var unique []string
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"testing"
+	"todo-api/internal/repository"
+)
+
+func TestTSQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		terms []repository.SearchTerm
+		want  string
+	}{
+		{"no terms", nil, ""},
+		{"single word", []repository.SearchTerm{{Words: []string{"fix"}}}, "(fix)"},
+		{"prefix", []repository.SearchTerm{{Words: []string{"depl"}, Prefix: true}}, "(depl:*)"},
+		{"phrase", []repository.SearchTerm{{Words: []string{"follow", "up"}}}, "(follow <-> up)"},
+		{"prefix phrase", []repository.SearchTerm{{Words: []string{"release", "not"}, Prefix: true}}, "(release <-> not:*)"},
+		{"every term must match", []repository.SearchTerm{
+			{Words: []string{"release", "notes"}},
+			{Words: []string{"draft"}},
+		}, "(release <-> notes) & (draft)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tsQuery(tt.terms); got != tt.want {
+				t.Errorf("tsQuery() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
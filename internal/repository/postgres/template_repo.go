@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"todo-api/internal/repository"
+)
+
+const templateColumns = `id, user_id, workspace_id, name, shared, task, created_at, updated_at`
+
+type templateRepository struct {
+	db querier
+}
+
+func NewTemplateRepository(db *sql.DB) repository.TemplateRepository {
+	return &templateRepository{db: db}
+}
+
+func scanTemplate(row rowScanner) (repository.Template, error) {
+	var template repository.Template
+	var task []byte
+	err := row.Scan(
+		&template.ID,
+		&template.UserID,
+		&template.WorkspaceID,
+		&template.Name,
+		&template.Shared,
+		&task,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+	)
+	if err != nil {
+		return template, err
+	}
+
+	if err := json.Unmarshal(task, &template.Task); err != nil {
+		return template, err
+	}
+
+	return template, nil
+}
+
+func (r *templateRepository) Create(template repository.Template) error {
+	task, err := json.Marshal(template.Task)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO task_templates (id, user_id, workspace_id, name, shared, task, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err = r.db.Exec(query,
+		template.ID,
+		template.UserID,
+		template.WorkspaceID,
+		template.Name,
+		template.Shared,
+		task,
+		template.CreatedAt,
+		template.UpdatedAt,
+	)
+
+	return err
+}
+
+func (r *templateRepository) GetByID(id string) (*repository.Template, error) {
+	query := `SELECT ` + templateColumns + ` FROM task_templates WHERE id = $1`
+
+	template, err := scanTemplate(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+func (r *templateRepository) GetVisible(userID, workspaceID string) ([]repository.Template, error) {
+	query := `
+		SELECT ` + templateColumns + `
+		FROM task_templates
+		WHERE workspace_id = $2 AND (user_id = $1 OR shared)
+		ORDER BY user_id <> $1, name, id
+	`
+
+	rows, err := r.db.Query(query, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var templates []repository.Template
+	for rows.Next() {
+		template, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+
+	return templates, rows.Err()
+}
+
+func (r *templateRepository) Update(template repository.Template) error {
+	task, err := json.Marshal(template.Task)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE task_templates
+		SET name = $2, shared = $3, task = $4, updated_at = $5
+		WHERE id = $1
+	`
+
+	_, err = r.db.Exec(query,
+		template.ID,
+		template.Name,
+		template.Shared,
+		task,
+		template.UpdatedAt,
+	)
+
+	return err
+}
+
+func (r *templateRepository) Delete(id string) error {
+	query := `DELETE FROM task_templates WHERE id = $1`
+	_, err := r.db.Exec(query, id)
+	return err
+}
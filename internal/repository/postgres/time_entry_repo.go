@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"database/sql"
+	"todo-api/internal/repository"
+
+	"github.com/lib/pq"
+)
+
+const timeEntryColumns = `id, task_id, user_id, started_at, stopped_at, note, created_at`
+
+type timeEntryRepository struct {
+	db querier
+}
+
+func NewTimeEntryRepository(db *sql.DB) repository.TimeEntryRepository {
+	return &timeEntryRepository{db: db}
+}
+
+func scanTimeEntry(row rowScanner) (repository.TimeEntry, error) {
+	var entry repository.TimeEntry
+	var stoppedAt sql.NullTime
+	err := row.Scan(
+		&entry.ID,
+		&entry.TaskID,
+		&entry.UserID,
+		&entry.StartedAt,
+		&stoppedAt,
+		&entry.Note,
+		&entry.CreatedAt,
+	)
+	if err != nil {
+		return entry, err
+	}
+
+	if stoppedAt.Valid {
+		entry.StoppedAt = &stoppedAt.Time
+	}
+
+	return entry, nil
+}
+
+func (r *timeEntryRepository) Create(entry repository.TimeEntry) error {
+	query := `
+		INSERT INTO time_entries (id, task_id, user_id, started_at, stopped_at, note, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.Exec(query,
+		entry.ID,
+		entry.TaskID,
+		entry.UserID,
+		entry.StartedAt,
+		entry.StoppedAt,
+		entry.Note,
+		entry.CreatedAt,
+	)
+
+	return err
+}
+
+func (r *timeEntryRepository) GetByID(id string) (*repository.TimeEntry, error) {
+	query := `SELECT ` + timeEntryColumns + ` FROM time_entries WHERE id = $1`
+
+	entry, err := scanTimeEntry(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+func (r *timeEntryRepository) GetRunning(userID string) (*repository.TimeEntry, error) {
+	query := `SELECT ` + timeEntryColumns + ` FROM time_entries WHERE user_id = $1 AND stopped_at IS NULL`
+
+	entry, err := scanTimeEntry(r.db.QueryRow(query, userID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+func (r *timeEntryRepository) List(filter repository.TimeEntryFilter) ([]repository.TimeEntry, error) {
+	var b queryBuilder
+
+	if filter.TaskIDs != nil {
+		b.where("task_id = ANY(?)", pq.Array(filter.TaskIDs))
+	}
+
+	if filter.UserID != "" {
+		b.where("user_id = ?", filter.UserID)
+	}
+
+	if filter.StartedAfter != nil {
+		b.where("started_at >= ?", *filter.StartedAfter)
+	}
+
+	if filter.StartedBefore != nil {
+		b.where("started_at < ?", *filter.StartedBefore)
+	}
+
+	query := `SELECT ` + timeEntryColumns + ` FROM time_entries ` + b.whereClause() + ` ORDER BY started_at, id`
+
+	rows, err := r.db.Query(query, b.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []repository.TimeEntry
+	for rows.Next() {
+		entry, err := scanTimeEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func (r *timeEntryRepository) Update(entry repository.TimeEntry) error {
+	query := `
+		UPDATE time_entries
+		SET started_at = $2, stopped_at = $3, note = $4
+		WHERE id = $1
+	`
+
+	_, err := r.db.Exec(query,
+		entry.ID,
+		entry.StartedAt,
+		entry.StoppedAt,
+		entry.Note,
+	)
+
+	return err
+}
+
+func (r *timeEntryRepository) Delete(id string) error {
+	query := `DELETE FROM time_entries WHERE id = $1`
+	_, err := r.db.Exec(query, id)
+	return err
+}
@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"database/sql"
+	"todo-api/internal/repository"
+)
+
+type transactor struct {
+	db querier
+}
+
+func NewTransactor(db *sql.DB) repository.Transactor {
+	return &transactor{db: db}
+}
+
+func (t *transactor) InTransaction(fn func(repo *repository.Repository) error) error {
+	return inTx(t.db, func(tx querier) error {
+		return fn(&repository.Repository{
+			Task:       &taskRepository{db: tx},
+			User:       &userRepository{db: tx},
+			Tag:        &tagRepository{db: tx},
+			Project:    &projectRepository{db: tx},
+			Comment:    &commentRepository{db: tx},
+			Attachment: &attachmentRepository{db: tx},
+			Workspace:  &workspaceRepository{db: tx},
+			Transition: &transitionRepository{db: tx},
+			Workflow:   &workflowRepository{db: tx},
+			History:    &historyRepository{db: tx},
+			TimeEntry:  &timeEntryRepository{db: tx},
+			View:       &viewRepository{db: tx},
+			Template:   &templateRepository{db: tx},
+			Transactor: &transactor{db: tx},
+		})
+	})
+}
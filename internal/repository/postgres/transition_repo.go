@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"database/sql"
+	"todo-api/internal/repository"
+)
+
+type transitionRepository struct {
+	db querier
+}
+
+func NewTransitionRepository(db *sql.DB) repository.TransitionRepository {
+	return &transitionRepository{db: db}
+}
+
+func (r *transitionRepository) Create(transition repository.TaskTransition) error {
+	query := `
+		INSERT INTO task_transitions (id, task_id, from_status, to_status, user_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.Exec(query,
+		transition.ID,
+		transition.TaskID,
+		transition.FromStatus,
+		transition.ToStatus,
+		transition.UserID,
+		transition.CreatedAt,
+	)
+
+	return err
+}
+
+func (r *transitionRepository) GetByTaskID(taskID string) ([]repository.TaskTransition, error) {
+	query := `
+		SELECT id, task_id, from_status, to_status, user_id, created_at
+		FROM task_transitions
+		WHERE task_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.db.Query(query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transitions []repository.TaskTransition
+	for rows.Next() {
+		var transition repository.TaskTransition
+		if err := rows.Scan(
+			&transition.ID,
+			&transition.TaskID,
+			&transition.FromStatus,
+			&transition.ToStatus,
+			&transition.UserID,
+			&transition.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		transitions = append(transitions, transition)
+	}
+
+	return transitions, rows.Err()
+}
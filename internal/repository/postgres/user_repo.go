@@ -5,18 +5,50 @@ import (
 	"todo-api/internal/repository"
 )
 
+const userColumns = `id, name, email, password, workspace_id`
+
 type userRepository struct {
-	db *sql.DB
+	db querier
 }
 
 func NewUserRepository(db *sql.DB) repository.UserRepository {
 	return &userRepository{db: db}
 }
 
+func scanUser(row rowScanner) (repository.User, error) {
+	var user repository.User
+	var workspaceID sql.NullString
+	err := row.Scan(
+		&user.ID,
+		&user.Name,
+		&user.Email,
+		&user.Password,
+		&workspaceID,
+	)
+
+	user.WorkspaceID = workspaceID.String
+	return user, err
+}
+
+func scanUsers(rows *sql.Rows) ([]repository.User, error) {
+	defer rows.Close()
+
+	var users []repository.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
 func (r *userRepository) Create(user repository.User) error {
 	query := `
-		INSERT INTO users (id, name, email, password)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO users (id, name, email, password, workspace_id)
+		VALUES ($1, $2, $3, $4, $5)
 	`
 
 	_, err := r.db.Exec(query,
@@ -24,6 +56,7 @@ func (r *userRepository) Create(user repository.User) error {
 		user.Name,
 		user.Email,
 		user.Password,
+		nullString(user.WorkspaceID),
 	)
 
 	return err
@@ -31,18 +64,12 @@ func (r *userRepository) Create(user repository.User) error {
 
 func (r *userRepository) GetByID(id string) (*repository.User, error) {
 	query := `
-		SELECT id, name, email, password
+		SELECT ` + userColumns + `
 		FROM users
 		WHERE id = $1
 	`
 
-	var user repository.User
-	err := r.db.QueryRow(query, id).Scan(
-		&user.ID,
-		&user.Name,
-		&user.Email,
-		&user.Password,
-	)
+	user, err := scanUser(r.db.QueryRow(query, id))
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -57,18 +84,12 @@ func (r *userRepository) GetByID(id string) (*repository.User, error) {
 
 func (r *userRepository) GetByEmail(email string) (*repository.User, error) {
 	query := `
-		SELECT id, name, email, password
+		SELECT ` + userColumns + `
 		FROM users
 		WHERE email = $1
 	`
 
-	var user repository.User
-	err := r.db.QueryRow(query, email).Scan(
-		&user.ID,
-		&user.Name,
-		&user.Email,
-		&user.Password,
-	)
+	user, err := scanUser(r.db.QueryRow(query, email))
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -83,7 +104,7 @@ func (r *userRepository) GetByEmail(email string) (*repository.User, error) {
 
 func (r *userRepository) GetAll() ([]repository.User, error) {
 	query := `
-		SELECT id, name, email, password
+		SELECT ` + userColumns + `
 		FROM users
 		ORDER BY created_at DESC
 	`
@@ -92,29 +113,31 @@ func (r *userRepository) GetAll() ([]repository.User, error) {
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	var users []repository.User
-	for rows.Next() {
-		var user repository.User
-		if err := rows.Scan(
-			&user.ID,
-			&user.Name,
-			&user.Email,
-			&user.Password,
-		); err != nil {
-			return nil, err
-		}
-		users = append(users, user)
+	return scanUsers(rows)
+}
+
+func (r *userRepository) GetByWorkspaceID(workspaceID string) ([]repository.User, error) {
+	query := `
+		SELECT u.id, u.name, u.email, u.password, u.workspace_id
+		FROM users u
+		JOIN workspace_members wm ON wm.user_id = u.id
+		WHERE wm.workspace_id = $1
+		ORDER BY wm.created_at
+	`
+
+	rows, err := r.db.Query(query, workspaceID)
+	if err != nil {
+		return nil, err
 	}
 
-	return users, nil
+	return scanUsers(rows)
 }
 
 func (r *userRepository) Update(user repository.User) error {
 	query := `
 		UPDATE users
-		SET name = $2, email = $3, password = $4, updated_at = CURRENT_TIMESTAMP
+		SET name = $2, email = $3, password = $4, workspace_id = $5, updated_at = CURRENT_TIMESTAMP
 		WHERE id = $1
 	`
 
@@ -123,6 +146,7 @@ func (r *userRepository) Update(user repository.User) error {
 		user.Name,
 		user.Email,
 		user.Password,
+		nullString(user.WorkspaceID),
 	)
 
 	return err
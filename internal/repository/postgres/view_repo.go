@@ -0,0 +1,193 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"todo-api/internal/repository"
+)
+
+const viewColumns = `id, user_id, workspace_id, name, filter, created_at, updated_at`
+
+type viewRepository struct {
+	db querier
+}
+
+func NewViewRepository(db *sql.DB) repository.ViewRepository {
+	return &viewRepository{db: db}
+}
+
+func scanView(row rowScanner) (repository.View, error) {
+	var view repository.View
+	var filter []byte
+	err := row.Scan(
+		&view.ID,
+		&view.UserID,
+		&view.WorkspaceID,
+		&view.Name,
+		&filter,
+		&view.CreatedAt,
+		&view.UpdatedAt,
+	)
+	if err != nil {
+		return view, err
+	}
+
+	if err := json.Unmarshal(filter, &view.Filter); err != nil {
+		return view, err
+	}
+
+	return view, nil
+}
+
+func scanViews(rows *sql.Rows) ([]repository.View, error) {
+	defer rows.Close()
+
+	var views []repository.View
+	for rows.Next() {
+		view, err := scanView(rows)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+
+	return views, rows.Err()
+}
+
+func (r *viewRepository) Create(view repository.View) error {
+	filter, err := json.Marshal(view.Filter)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO views (id, user_id, workspace_id, name, filter, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err = r.db.Exec(query,
+		view.ID,
+		view.UserID,
+		view.WorkspaceID,
+		view.Name,
+		filter,
+		view.CreatedAt,
+		view.UpdatedAt,
+	)
+
+	return err
+}
+
+func (r *viewRepository) GetByID(id string) (*repository.View, error) {
+	query := `SELECT ` + viewColumns + ` FROM views WHERE id = $1`
+
+	view, err := scanView(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &view, nil
+}
+
+func (r *viewRepository) GetByUserID(userID, workspaceID string) ([]repository.View, error) {
+	query := `
+		SELECT ` + viewColumns + `
+		FROM views
+		WHERE user_id = $1 AND workspace_id = $2
+		ORDER BY name, id
+	`
+
+	rows, err := r.db.Query(query, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanViews(rows)
+}
+
+func (r *viewRepository) GetSharedWithUser(userID, workspaceID string) ([]repository.View, error) {
+	query := `
+		SELECT v.id, v.user_id, v.workspace_id, v.name, v.filter, v.created_at, v.updated_at
+		FROM views v
+		JOIN view_shares vs ON vs.view_id = v.id
+		WHERE vs.user_id = $1 AND v.workspace_id = $2
+		ORDER BY v.name, v.id
+	`
+
+	rows, err := r.db.Query(query, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanViews(rows)
+}
+
+func (r *viewRepository) Update(view repository.View) error {
+	filter, err := json.Marshal(view.Filter)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE views
+		SET name = $2, filter = $3, updated_at = $4
+		WHERE id = $1
+	`
+
+	_, err = r.db.Exec(query,
+		view.ID,
+		view.Name,
+		filter,
+		view.UpdatedAt,
+	)
+
+	return err
+}
+
+func (r *viewRepository) Delete(id string) error {
+	query := `DELETE FROM views WHERE id = $1`
+	_, err := r.db.Exec(query, id)
+	return err
+}
+
+func (r *viewRepository) SetShares(viewID string, userIDs []string) error {
+	return inTx(r.db, func(tx querier) error {
+		if _, err := tx.Exec(`DELETE FROM view_shares WHERE view_id = $1`, viewID); err != nil {
+			return err
+		}
+
+		insertQuery := `INSERT INTO view_shares (view_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+		for _, userID := range userIDs {
+			if _, err := tx.Exec(insertQuery, viewID, userID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *viewRepository) GetShares(viewID string) ([]string, error) {
+	query := `SELECT user_id FROM view_shares WHERE view_id = $1 ORDER BY user_id`
+
+	rows, err := r.db.Query(query, viewID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, rows.Err()
+}
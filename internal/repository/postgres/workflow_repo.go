@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"database/sql"
+	"todo-api/internal/repository"
+)
+
+type workflowRepository struct {
+	db querier
+}
+
+func NewWorkflowRepository(db *sql.DB) repository.WorkflowRepository {
+	return &workflowRepository{db: db}
+}
+
+func (r *workflowRepository) GetStatuses(projectID string) ([]repository.WorkflowStatus, error) {
+	query := `
+		SELECT id, project_id, name, position, is_done, wip_limit
+		FROM workflow_statuses
+		WHERE project_id IS NOT DISTINCT FROM $1
+		ORDER BY position
+	`
+
+	rows, err := r.db.Query(query, nullString(projectID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var statuses []repository.WorkflowStatus
+	for rows.Next() {
+		status, err := scanWorkflowStatus(rows)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, rows.Err()
+}
+
+func (r *workflowRepository) GetStatus(id string) (*repository.WorkflowStatus, error) {
+	query := `
+		SELECT id, project_id, name, position, is_done, wip_limit
+		FROM workflow_statuses
+		WHERE id = $1
+	`
+
+	status, err := scanWorkflowStatus(r.db.QueryRow(query, id))
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+func scanWorkflowStatus(row rowScanner) (repository.WorkflowStatus, error) {
+	var status repository.WorkflowStatus
+	var projectID sql.NullString
+	err := row.Scan(
+		&status.ID,
+		&projectID,
+		&status.Name,
+		&status.Position,
+		&status.Done,
+		&status.WIPLimit,
+	)
+
+	status.ProjectID = projectID.String
+	return status, err
+}
+
+func (r *workflowRepository) GetTransitions(projectID string) ([]repository.WorkflowTransition, error) {
+	query := `
+		SELECT wt.from_status_id, wt.to_status_id
+		FROM workflow_transitions wt
+		JOIN workflow_statuses ws ON ws.id = wt.from_status_id
+		WHERE ws.project_id IS NOT DISTINCT FROM $1
+	`
+
+	rows, err := r.db.Query(query, nullString(projectID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transitions []repository.WorkflowTransition
+	for rows.Next() {
+		var transition repository.WorkflowTransition
+		if err := rows.Scan(&transition.FromStatusID, &transition.ToStatusID); err != nil {
+			return nil, err
+		}
+		transitions = append(transitions, transition)
+	}
+
+	return transitions, rows.Err()
+}
+
+// SaveWorkflow upserts the given statuses of a project and replaces its
+// transitions; statuses that are no longer used are removed with DeleteStatus.
+func (r *workflowRepository) SaveWorkflow(projectID string, statuses []repository.WorkflowStatus, transitions []repository.WorkflowTransition) error {
+	return inTx(r.db, func(tx querier) error {
+		// Positions and names are unique per project, so park the current ones
+		// out of the way before writing the new order.
+		parkQuery := `
+			UPDATE workflow_statuses SET position = -position - 1, name = id
+			WHERE project_id = $1
+		`
+		if _, err := tx.Exec(parkQuery, projectID); err != nil {
+			return err
+		}
+
+		upsertQuery := `
+			INSERT INTO workflow_statuses (id, project_id, name, position, is_done, wip_limit)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (id) DO UPDATE
+			SET name = EXCLUDED.name, position = EXCLUDED.position, is_done = EXCLUDED.is_done, wip_limit = EXCLUDED.wip_limit
+		`
+		for _, status := range statuses {
+			if _, err := tx.Exec(upsertQuery, status.ID, projectID, status.Name, status.Position, status.Done, status.WIPLimit); err != nil {
+				return err
+			}
+		}
+
+		deleteTransitionsQuery := `
+			DELETE FROM workflow_transitions
+			WHERE from_status_id IN (SELECT id FROM workflow_statuses WHERE project_id = $1)
+		`
+		if _, err := tx.Exec(deleteTransitionsQuery, projectID); err != nil {
+			return err
+		}
+
+		insertTransitionQuery := `INSERT INTO workflow_transitions (from_status_id, to_status_id) VALUES ($1, $2)`
+		for _, transition := range transitions {
+			if _, err := tx.Exec(insertTransitionQuery, transition.FromStatusID, transition.ToStatusID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *workflowRepository) DeleteStatus(id string) error {
+	query := `DELETE FROM workflow_statuses WHERE id = $1`
+	_, err := r.db.Exec(query, id)
+	return err
+}
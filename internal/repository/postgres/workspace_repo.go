@@ -0,0 +1,244 @@
+package postgres
+
+import (
+	"database/sql"
+	"todo-api/internal/repository"
+)
+
+type workspaceRepository struct {
+	db querier
+}
+
+func NewWorkspaceRepository(db *sql.DB) repository.WorkspaceRepository {
+	return &workspaceRepository{db: db}
+}
+
+func (r *workspaceRepository) Create(workspace repository.Workspace) error {
+	query := `
+		INSERT INTO workspaces (id, name, owner_id, created_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	_, err := r.db.Exec(query,
+		workspace.ID,
+		workspace.Name,
+		workspace.OwnerID,
+		workspace.CreatedAt,
+	)
+
+	return err
+}
+
+func (r *workspaceRepository) GetByID(id string) (*repository.Workspace, error) {
+	query := `
+		SELECT id, name, owner_id, created_at
+		FROM workspaces
+		WHERE id = $1
+	`
+
+	var workspace repository.Workspace
+	err := r.db.QueryRow(query, id).Scan(
+		&workspace.ID,
+		&workspace.Name,
+		&workspace.OwnerID,
+		&workspace.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &workspace, nil
+}
+
+func (r *workspaceRepository) GetByUserID(userID string) ([]repository.Workspace, error) {
+	query := `
+		SELECT w.id, w.name, w.owner_id, w.created_at
+		FROM workspaces w
+		JOIN workspace_members wm ON wm.workspace_id = w.id
+		WHERE wm.user_id = $1
+		ORDER BY w.created_at
+	`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var workspaces []repository.Workspace
+	for rows.Next() {
+		var workspace repository.Workspace
+		if err := rows.Scan(
+			&workspace.ID,
+			&workspace.Name,
+			&workspace.OwnerID,
+			&workspace.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		workspaces = append(workspaces, workspace)
+	}
+
+	return workspaces, rows.Err()
+}
+
+func (r *workspaceRepository) SaveMember(member repository.WorkspaceMember) error {
+	query := `
+		INSERT INTO workspace_members (workspace_id, user_id, role, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (workspace_id, user_id) DO UPDATE SET role = EXCLUDED.role
+	`
+
+	_, err := r.db.Exec(query,
+		member.WorkspaceID,
+		member.UserID,
+		member.Role,
+		member.CreatedAt,
+	)
+
+	return err
+}
+
+func (r *workspaceRepository) GetMember(workspaceID, userID string) (*repository.WorkspaceMember, error) {
+	query := `
+		SELECT workspace_id, user_id, role, created_at
+		FROM workspace_members
+		WHERE workspace_id = $1 AND user_id = $2
+	`
+
+	var member repository.WorkspaceMember
+	err := r.db.QueryRow(query, workspaceID, userID).Scan(
+		&member.WorkspaceID,
+		&member.UserID,
+		&member.Role,
+		&member.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (r *workspaceRepository) GetMembers(workspaceID string) ([]repository.WorkspaceMember, error) {
+	query := `
+		SELECT workspace_id, user_id, role, created_at
+		FROM workspace_members
+		WHERE workspace_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(query, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []repository.WorkspaceMember
+	for rows.Next() {
+		var member repository.WorkspaceMember
+		if err := rows.Scan(
+			&member.WorkspaceID,
+			&member.UserID,
+			&member.Role,
+			&member.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	return members, rows.Err()
+}
+
+func (r *workspaceRepository) SaveInvitation(invitation repository.WorkspaceInvitation) error {
+	query := `
+		INSERT INTO workspace_invitations (id, workspace_id, user_id, invited_by, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := r.db.Exec(query,
+		invitation.ID,
+		invitation.WorkspaceID,
+		invitation.UserID,
+		invitation.InvitedBy,
+		invitation.CreatedAt,
+	)
+
+	return err
+}
+
+func (r *workspaceRepository) GetInvitation(id string) (*repository.WorkspaceInvitation, error) {
+	query := `
+		SELECT id, workspace_id, user_id, invited_by, created_at
+		FROM workspace_invitations
+		WHERE id = $1
+	`
+
+	var invitation repository.WorkspaceInvitation
+	err := r.db.QueryRow(query, id).Scan(
+		&invitation.ID,
+		&invitation.WorkspaceID,
+		&invitation.UserID,
+		&invitation.InvitedBy,
+		&invitation.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &invitation, nil
+}
+
+func (r *workspaceRepository) GetInvitationsByUserID(userID string) ([]repository.WorkspaceInvitation, error) {
+	query := `
+		SELECT id, workspace_id, user_id, invited_by, created_at
+		FROM workspace_invitations
+		WHERE user_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invitations []repository.WorkspaceInvitation
+	for rows.Next() {
+		var invitation repository.WorkspaceInvitation
+		if err := rows.Scan(
+			&invitation.ID,
+			&invitation.WorkspaceID,
+			&invitation.UserID,
+			&invitation.InvitedBy,
+			&invitation.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	return invitations, rows.Err()
+}
+
+func (r *workspaceRepository) DeleteInvitation(id string) error {
+	query := `DELETE FROM workspace_invitations WHERE id = $1`
+	_, err := r.db.Exec(query, id)
+	return err
+}
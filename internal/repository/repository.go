@@ -1,12 +1,137 @@
 package repository
 
+import "time"
+
 type TaskRepository interface {
 	Create(task Task) error
 	GetByID(id string) (*Task, error)
-	GetAll() ([]Task, error)
+	List(filter TaskFilter) ([]Task, error)
 	GetByUserID(userID string) ([]Task, error)
+	GetChildren(parentID string) ([]Task, error)
 	Update(task Task) error
 	Delete(id string) error
+	AddTag(taskID, tagID string) error
+	RemoveTag(taskID, tagID string) error
+	GetTags(taskID string) ([]Tag, error)
+	AddDependency(taskID, dependsOnID string) error
+	RemoveDependency(taskID, dependsOnID string) error
+	GetDependencies(taskID string) ([]Task, error)
+	// GetDependencyIDs returns the IDs of the tasks taskID depends on,
+	// trashed tasks included.
+	GetDependencyIDs(taskID string) ([]string, error)
+	MoveProjectTasks(fromProjectID, toProjectID string) error
+	DeleteByProjectID(projectID string) error
+	ReplaceStatus(projectID, fromStatusID, toStatusID string) error
+	// SoftDelete moves a task and its subtasks to the trash. Trashed tasks are
+	// left out of every lookup except GetTrashed and List with Trashed set.
+	SoftDelete(id string, deletedAt time.Time) error
+	// Restore takes a task out of the trash together with the subtasks that
+	// were trashed along with it.
+	Restore(id string) error
+	GetTrashed(id string) (*Task, error)
+	// Search returns the tasks matching filter whose title or description
+	// match every term, best matches first.
+	Search(filter TaskFilter, search TaskSearch) ([]TaskSearchHit, error)
+}
+
+// WorkflowRepository stores task workflows. Statuses without a project form
+// the default workflow used by the Inbox and by projects without their own.
+type WorkflowRepository interface {
+	GetStatuses(projectID string) ([]WorkflowStatus, error)
+	GetStatus(id string) (*WorkflowStatus, error)
+	GetTransitions(projectID string) ([]WorkflowTransition, error)
+	SaveWorkflow(projectID string, statuses []WorkflowStatus, transitions []WorkflowTransition) error
+	DeleteStatus(id string) error
+}
+
+type ProjectRepository interface {
+	Create(project Project) error
+	GetByID(id string) (*Project, error)
+	GetByUserID(userID, workspaceID string) ([]Project, error)
+	GetSharedWithUser(userID, workspaceID string) ([]Project, error)
+	Update(project Project) error
+	Delete(id string) error
+	SaveMember(member ProjectMember) error
+	GetMember(projectID, userID string) (*ProjectMember, error)
+	GetMembers(projectID string) ([]ProjectMember, error)
+	RemoveMember(projectID, userID string) error
+}
+
+type TagRepository interface {
+	Create(tag Tag) error
+	GetByID(id string) (*Tag, error)
+	GetByUserID(userID, workspaceID string) ([]Tag, error)
+	Update(tag Tag) error
+	Delete(id string) error
+}
+
+type CommentRepository interface {
+	Create(comment Comment) error
+	GetByID(id string) (*Comment, error)
+	GetByTaskID(taskID string, limit, offset int) ([]Comment, error)
+	CountByTaskID(taskID string) (int, error)
+	Update(comment Comment) error
+	Delete(id string) error
+}
+
+type AttachmentRepository interface {
+	Create(attachment Attachment) error
+	GetByID(id string) (*Attachment, error)
+	GetByTaskID(taskID string) ([]Attachment, error)
+	CountByChecksum(checksum string) (int, error)
+	Delete(id string) error
+}
+
+type TransitionRepository interface {
+	Create(transition TaskTransition) error
+	GetByTaskID(taskID string) ([]TaskTransition, error)
+}
+
+type HistoryRepository interface {
+	Create(entry TaskHistoryEntry) error
+	GetByTaskID(taskID string) ([]TaskHistoryEntry, error)
+}
+
+// TimeEntryRepository stores tracked time. An entry without StoppedAt is a
+// running timer; each user has at most one.
+type TimeEntryRepository interface {
+	Create(entry TimeEntry) error
+	GetByID(id string) (*TimeEntry, error)
+	GetRunning(userID string) (*TimeEntry, error)
+	List(filter TimeEntryFilter) ([]TimeEntry, error)
+	Update(entry TimeEntry) error
+	Delete(id string) error
+}
+
+// ViewRepository stores saved task views and the users they are shared with.
+type ViewRepository interface {
+	Create(view View) error
+	GetByID(id string) (*View, error)
+	GetByUserID(userID, workspaceID string) ([]View, error)
+	GetSharedWithUser(userID, workspaceID string) ([]View, error)
+	Update(view View) error
+	Delete(id string) error
+	// SetShares replaces the users a view is shared with.
+	SetShares(viewID string, userIDs []string) error
+	GetShares(viewID string) ([]string, error)
+}
+
+// TemplateRepository stores task templates. A shared template is visible to
+// every member of its workspace.
+type TemplateRepository interface {
+	Create(template Template) error
+	GetByID(id string) (*Template, error)
+	// GetVisible lists the templates userID owns in a workspace followed by
+	// the ones others shared in it.
+	GetVisible(userID, workspaceID string) ([]Template, error)
+	Update(template Template) error
+	Delete(id string) error
+}
+
+// Transactor runs fn with repositories whose changes are applied together,
+// or not at all when fn returns an error.
+type Transactor interface {
+	InTransaction(fn func(repo *Repository) error) error
 }
 
 type UserRepository interface {
@@ -14,26 +139,333 @@ type UserRepository interface {
 	GetByID(id string) (*User, error)
 	GetByEmail(email string) (*User, error)
 	GetAll() ([]User, error)
+	GetByWorkspaceID(workspaceID string) ([]User, error)
 	Update(user User) error
 	Delete(id string) error
 }
 
+type WorkspaceRepository interface {
+	Create(workspace Workspace) error
+	GetByID(id string) (*Workspace, error)
+	GetByUserID(userID string) ([]Workspace, error)
+	SaveMember(member WorkspaceMember) error
+	GetMember(workspaceID, userID string) (*WorkspaceMember, error)
+	GetMembers(workspaceID string) ([]WorkspaceMember, error)
+	SaveInvitation(invitation WorkspaceInvitation) error
+	GetInvitation(id string) (*WorkspaceInvitation, error)
+	GetInvitationsByUserID(userID string) ([]WorkspaceInvitation, error)
+	DeleteInvitation(id string) error
+}
+
 type Task struct {
+	ID             string     `json:"id"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	StatusID       string     `json:"status_id"`
+	Status         string     `json:"status"`
+	StatusPosition int        `json:"status_position"`
+	Done           bool       `json:"done"`
+	BoardPosition  float64    `json:"board_position"`
+	UserID         string     `json:"user_id"`
+	DueAt          *time.Time `json:"due_at"`
+	DueHasTime     bool       `json:"due_has_time"`
+	DueTimezone    string     `json:"due_timezone"`
+	Priority       int        `json:"priority"`
+	Estimate       *float64   `json:"estimate"`
+	CreatedAt      time.Time  `json:"created_at"`
+	CompletedAt    *time.Time `json:"completed_at"`
+	Tags           []string   `json:"tags"`
+	ParentID       string     `json:"parent_id"`
+	BlockedBy      []string   `json:"blocked_by"`
+	Blocks         []string   `json:"blocks"`
+	Recurrence     string     `json:"recurrence"`
+	ProjectID      string     `json:"project_id"`
+	AssigneeID     string     `json:"assignee_id"`
+	WorkspaceID    string     `json:"workspace_id"`
+	ArchivedAt     *time.Time `json:"archived_at"`
+	DeletedAt      *time.Time `json:"deleted_at"`
+}
+
+type TaskFilter struct {
+	// WorkspaceID limits the listing to one workspace.
+	WorkspaceID string
+	// ProjectID limits the listing to one project; an empty string selects the Inbox.
+	ProjectID *string
+	// AssigneeID limits the listing to one assignee; an empty string selects unassigned tasks.
+	AssigneeID *string
+	// ParentID limits the listing to the direct subtasks of a task.
+	ParentID *string
+	// StatusID limits the listing to one workflow status, i.e. one board column.
+	StatusID string
+	// Status limits the listing to statuses of this name, in any workflow.
+	Status string
+	// UserID limits the listing to the tasks one user created.
+	UserID string
+	// Trashed lists soft-deleted tasks instead of live ones.
+	Trashed       bool
+	DeletedBefore *time.Time
+	// VisibleTo limits the listing to tasks the user owns, is assigned to or
+	// can see through one of VisibleProjectIDs.
+	VisibleTo         string
+	VisibleProjectIDs []string
+	DueBefore         *time.Time
+	DueAfter          *time.Time
+	CreatedBefore     *time.Time
+	CreatedAfter      *time.Time
+	CompletedBefore   *time.Time
+	CompletedAfter    *time.Time
+	// Text limits the listing to tasks whose title or description contain
+	// it, ignoring case.
+	Text            string
+	ExcludeDone     bool
+	OnlyDone        bool
+	ExcludeArchived bool
+	// Tags limits the listing to tasks carrying tags of these names, as owned
+	// by TagUserID.
+	Tags         []string
+	TagUserID    string
+	MatchAllTags bool
+	Sort         []SortKey
+	// After continues the listing with the tasks sorted behind this one. Only
+	// the fields used by Sort, CreatedAt and ID need to be set.
+	After *Task
+	// Limit caps the number of tasks listed; 0 lists them all.
+	Limit int
+}
+
+const (
+	SortByCreated   = "created"
+	SortByDue       = "due"
+	SortByPriority  = "priority"
+	SortByTitle     = "title"
+	SortByStatus    = "status"
+	SortByPosition  = "position"
+	SortByDeleted   = "deleted"
+	SortByCompleted = "completed"
+)
+
+type TaskSearch struct {
+	Terms []SearchTerm
+	Limit int
+}
+
+// SearchTerm matches tasks containing Words next to each other, in order.
+// With Prefix the last word only has to start a word of the task.
+type SearchTerm struct {
+	Words  []string
+	Prefix bool
+}
+
+type TaskSearchHit struct {
+	Task    Task
+	Rank    float64
+	Snippet string
+}
+
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+type Project struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description"`
+	UserID       string    `json:"user_id"`
+	WorkspaceID  string    `json:"workspace_id"`
+	EstimateUnit string    `json:"estimate_unit"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type WorkflowStatus struct {
+	ID        string `json:"id"`
+	ProjectID string `json:"project_id"`
+	Name      string `json:"name"`
+	Position  int    `json:"position"`
+	Done      bool   `json:"done"`
+	WIPLimit  int    `json:"wip_limit"`
+}
+
+type WorkflowTransition struct {
+	FromStatusID string `json:"from_status_id"`
+	ToStatusID   string `json:"to_status_id"`
+}
+
+type TaskTransition struct {
+	ID         string    `json:"id"`
+	TaskID     string    `json:"task_id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	UserID     string    `json:"user_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type TaskHistoryEntry struct {
+	ID        string        `json:"id"`
+	TaskID    string        `json:"task_id"`
+	UserID    string        `json:"user_id"`
+	Action    string        `json:"action"`
+	Changes   []FieldChange `json:"changes"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type FieldChange struct {
+	Field    string `json:"field"`
+	OldValue string `json:"old"`
+	NewValue string `json:"new"`
+}
+
+type TimeEntry struct {
+	ID        string     `json:"id"`
+	TaskID    string     `json:"task_id"`
+	UserID    string     `json:"user_id"`
+	StartedAt time.Time  `json:"started_at"`
+	StoppedAt *time.Time `json:"stopped_at"`
+	Note      string     `json:"note"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type TimeEntryFilter struct {
+	// TaskIDs limits the listing to entries of these tasks; nil selects every task.
+	TaskIDs       []string
+	UserID        string
+	StartedAfter  *time.Time
+	StartedBefore *time.Time
+}
+
+type View struct {
+	ID          string     `json:"id"`
+	UserID      string     `json:"user_id"`
+	WorkspaceID string     `json:"workspace_id"`
+	Name        string     `json:"name"`
+	Filter      ViewFilter `json:"filter"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type ViewFilter struct {
+	Overdue         bool     `json:"overdue,omitempty"`
+	DueBefore       string   `json:"due_before,omitempty"`
+	DueAfter        string   `json:"due_after,omitempty"`
+	CreatedBefore   string   `json:"created_before,omitempty"`
+	CreatedAfter    string   `json:"created_after,omitempty"`
+	CompletedBefore string   `json:"completed_before,omitempty"`
+	CompletedAfter  string   `json:"completed_after,omitempty"`
+	Done            *bool    `json:"done,omitempty"`
+	Status          string   `json:"status,omitempty"`
+	Owner           string   `json:"owner,omitempty"`
+	Text            string   `json:"text,omitempty"`
+	Sort            string   `json:"sort,omitempty"`
+	Tags            []string `json:"tags,omitempty"`
+	TagMode         string   `json:"tag_mode,omitempty"`
+	ProjectID       string   `json:"project_id,omitempty"`
+	Assignee        string   `json:"assignee,omitempty"`
+	IncludeArchived bool     `json:"include_archived,omitempty"`
+}
+
+type Template struct {
+	ID          string       `json:"id"`
+	UserID      string       `json:"user_id"`
+	WorkspaceID string       `json:"workspace_id"`
+	Name        string       `json:"name"`
+	Shared      bool         `json:"shared"`
+	Task        TemplateTask `json:"task"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// TemplateTask is a task created from a template, along with its subtasks.
+type TemplateTask struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	Priority    string         `json:"priority,omitempty"`
+	Estimate    *float64       `json:"estimate,omitempty"`
+	DueInDays   *int           `json:"due_in_days,omitempty"`
+	DueTime     string         `json:"due_time,omitempty"`
+	Items       []TemplateTask `json:"items,omitempty"`
+}
+
+type Workspace struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	OwnerID   string    `json:"owner_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type WorkspaceMember struct {
+	WorkspaceID string    `json:"workspace_id"`
+	UserID      string    `json:"user_id"`
+	Role        string    `json:"role"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// WorkspaceInvitation is a pending membership the invited user has yet to
+// accept.
+type WorkspaceInvitation struct {
+	ID          string    `json:"id"`
+	WorkspaceID string    `json:"workspace_id"`
+	UserID      string    `json:"user_id"`
+	InvitedBy   string    `json:"invited_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type Comment struct {
+	ID        string    `json:"id"`
+	TaskID    string    `json:"task_id"`
+	UserID    string    `json:"user_id"`
+	Body      string    `json:"body"`
+	Edited    bool      `json:"edited"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Attachment struct {
+	ID          string    `json:"id"`
+	TaskID      string    `json:"task_id"`
+	UserID      string    `json:"user_id"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Checksum    string    `json:"checksum"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type ProjectMember struct {
+	ProjectID string    `json:"project_id"`
+	UserID    string    `json:"user_id"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Tag struct {
 	ID          string `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Status      string `json:"status"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
 	UserID      string `json:"user_id"`
+	WorkspaceID string `json:"workspace_id"`
 }
 
 type User struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	Email    string `json:"email"`
-	Password string `json:"-"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	Password    string `json:"-"`
+	WorkspaceID string `json:"workspace_id"`
 }
 
 type Repository struct {
-	Task TaskRepository
-	User UserRepository
+	Task       TaskRepository
+	User       UserRepository
+	Tag        TagRepository
+	Project    ProjectRepository
+	Comment    CommentRepository
+	Attachment AttachmentRepository
+	Workspace  WorkspaceRepository
+	Transition TransitionRepository
+	Workflow   WorkflowRepository
+	History    HistoryRepository
+	TimeEntry  TimeEntryRepository
+	View       ViewRepository
+	Template   TemplateRepository
+	Transactor Transactor
 }
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"errors"
+	"todo-api/internal/models"
+	"todo-api/internal/repository"
+)
+
+// accessControl decides what a user may do with tasks and projects. Nothing
+// outside the active workspace is visible; within it owners have full
+// access, project members act according to their role and assignees may
+// view a task and change its status.
+type accessControl struct {
+	taskRepo    repository.TaskRepository
+	projectRepo repository.ProjectRepository
+}
+
+func newAccessControl(repo *repository.Repository) accessControl {
+	return accessControl{
+		taskRepo:    repo.Task,
+		projectRepo: repo.Project,
+	}
+}
+
+// projectRole returns the role of userID on a project, or "" when the user
+// has no access to it.
+func (a accessControl) projectRole(project repository.Project, userID string) (models.ProjectRole, error) {
+	if project.UserID == userID {
+		return models.RoleOwner, nil
+	}
+
+	member, err := a.projectRepo.GetMember(project.ID, userID)
+	if err != nil {
+		return "", err
+	}
+
+	if member == nil {
+		return "", nil
+	}
+
+	return models.ProjectRole(member.Role), nil
+}
+
+// taskRole returns the role of userID on a task, which is inherited from the
+// task's project unless the user created the task.
+func (a accessControl) taskRole(task repository.Task, userID string) (models.ProjectRole, error) {
+	if task.UserID == userID {
+		return models.RoleOwner, nil
+	}
+
+	if task.ProjectID == "" {
+		return "", nil
+	}
+
+	project, err := a.projectRepo.GetByID(task.ProjectID)
+	if err != nil {
+		return "", err
+	}
+
+	if project == nil {
+		return "", nil
+	}
+
+	return a.projectRole(*project, userID)
+}
+
+func (a accessControl) checkTask(task repository.Task, userID, workspaceID string, required models.ProjectRole) error {
+	if task.WorkspaceID != workspaceID {
+		return errors.New("Task not found")
+	}
+
+	if required == models.RoleViewer && task.AssigneeID == userID {
+		return nil
+	}
+
+	role, err := a.taskRole(task, userID)
+	if err != nil {
+		return err
+	}
+
+	if !role.Allows(required) {
+		return errors.New("Access denied")
+	}
+
+	return nil
+}
+
+// getTask loads a task and checks that userID holds at least the required role on it.
+func (a accessControl) getTask(id, userID, workspaceID string, required models.ProjectRole) (*repository.Task, error) {
+	repoTask, err := a.taskRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if repoTask == nil {
+		return nil, errors.New("Task not found")
+	}
+
+	if err := a.checkTask(*repoTask, userID, workspaceID, required); err != nil {
+		return nil, err
+	}
+
+	return repoTask, nil
+}
+
+// getProject loads a project and checks that userID holds at least the required role on it.
+func (a accessControl) getProject(id, userID, workspaceID string, required models.ProjectRole) (*repository.Project, error) {
+	repoProject, err := a.projectRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if repoProject == nil || repoProject.WorkspaceID != workspaceID {
+		return nil, errors.New("Project not found")
+	}
+
+	role, err := a.projectRole(*repoProject, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if role == "" {
+		return nil, errors.New("Project not found")
+	}
+
+	if !role.Allows(required) {
+		return nil, errors.New("Access denied")
+	}
+
+	return repoProject, nil
+}
+
+// visibleProjectIDs lists the projects of a workspace whose tasks userID may see.
+func (a accessControl) visibleProjectIDs(userID, workspaceID string) ([]string, error) {
+	owned, err := a.projectRepo.GetByUserID(userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	shared, err := a.projectRepo.GetSharedWithUser(userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(owned)+len(shared))
+	for _, project := range append(owned, shared...) {
+		ids = append(ids, project.ID)
+	}
+
+	return ids, nil
+}
+
+// checkWorkspaceMember verifies that userID belongs to a workspace.
+func checkWorkspaceMember(repo repository.WorkspaceRepository, workspaceID, userID string) error {
+	member, err := repo.GetMember(workspaceID, userID)
+	if err != nil {
+		return err
+	}
+
+	if member == nil {
+		return errors.New("User not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"time"
+	"todo-api/internal/models"
+	"todo-api/internal/repository"
+)
+
+// ArchiveTask takes a finished task off the default task lists and the board.
+// Archived tasks stay readable and can be listed with include_archived.
+func (s *TaskService) ArchiveTask(id, userID, workspaceID string) (*models.Task, error) {
+	repoTask, err := s.access.getTask(id, userID, workspaceID, models.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	if !repoTask.Done {
+		return nil, &WorkflowError{message: "Only finished tasks can be archived"}
+	}
+
+	return s.setArchived(*repoTask, true, userID)
+}
+
+func (s *TaskService) UnarchiveTask(id, userID, workspaceID string) (*models.Task, error) {
+	repoTask, err := s.access.getTask(id, userID, workspaceID, models.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.setArchived(*repoTask, false, userID)
+}
+
+// ArchiveProjectTasks archives every finished task of a project.
+func (s *TaskService) ArchiveProjectTasks(projectID, userID, workspaceID string) (*models.ArchiveResult, error) {
+	if _, err := s.access.getProject(projectID, userID, workspaceID, models.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	repoTasks, err := s.repo.List(repository.TaskFilter{
+		WorkspaceID:     workspaceID,
+		ProjectID:       &projectID,
+		ExcludeArchived: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := models.ArchiveResult{}
+	for _, repoTask := range repoTasks {
+		if !repoTask.Done {
+			continue
+		}
+
+		if _, err := s.setArchived(repoTask, true, userID); err != nil {
+			return nil, err
+		}
+		result.Archived++
+	}
+
+	return &result, nil
+}
+
+func (s *TaskService) setArchived(repoTask repository.Task, archived bool, userID string) (*models.Task, error) {
+	if (repoTask.ArchivedAt != nil) == archived {
+		task := models.ConvertFromRepositoryTask(repoTask)
+		return &task, nil
+	}
+
+	before := models.ConvertFromRepositoryTask(repoTask)
+
+	repoTask.ArchivedAt = nil
+	if archived {
+		now := time.Now().UTC()
+		repoTask.ArchivedAt = &now
+	}
+
+	if err := s.repo.Update(repoTask); err != nil {
+		return nil, err
+	}
+
+	task := models.ConvertFromRepositoryTask(repoTask)
+	if err := s.recordHistory(&before, &task, userID); err != nil {
+		return nil, err
+	}
+
+	return &task, nil
+}
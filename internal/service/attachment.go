@@ -0,0 +1,235 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"todo-api/internal/models"
+	"todo-api/internal/repository"
+	"todo-api/internal/storage"
+
+	"github.com/google/uuid"
+)
+
+type AttachmentService struct {
+	repo    repository.AttachmentRepository
+	store   storage.BlobStore
+	access  accessControl
+	maxSize int64
+}
+
+func NewAttachmentService(repo *repository.Repository, store storage.BlobStore, maxSize int64) *AttachmentService {
+	return &AttachmentService{
+		repo:    repo.Attachment,
+		store:   store,
+		access:  newAccessControl(repo),
+		maxSize: maxSize,
+	}
+}
+
+// Upload stores the file contents under their SHA-256 checksum, so identical
+// files attached to several tasks share a single blob.
+func (s *AttachmentService) Upload(taskID, filename string, content io.Reader, userID, workspaceID string) (*models.Attachment, error) {
+	if _, err := s.access.getTask(taskID, userID, workspaceID, models.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	filename = filepath.Base(strings.ReplaceAll(filename, `\`, "/"))
+	if filename == "" || filename == "." || filename == "/" {
+		return nil, errors.New("File name is required")
+	}
+
+	tmp, err := os.CreateTemp("", "attachment-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), io.LimitReader(content, s.maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if size > s.maxSize {
+		return nil, errors.New("File exceeds the maximum upload size")
+	}
+
+	if size == 0 {
+		return nil, errors.New("File is empty")
+	}
+
+	head := make([]byte, 512)
+	n, err := tmp.ReadAt(head, 0)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	checksum := hex.EncodeToString(hash.Sum(nil))
+
+	unlock := lockBlob(checksum)
+	defer unlock()
+
+	exists, err := s.store.Exists(checksum)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+
+		if err := s.store.Put(checksum, tmp); err != nil {
+			return nil, err
+		}
+	}
+
+	attachment := models.Attachment{
+		ID:          uuid.New().String(),
+		TaskID:      taskID,
+		UserID:      userID,
+		Filename:    filename,
+		ContentType: http.DetectContentType(head[:n]),
+		Size:        size,
+		Checksum:    checksum,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	if err := s.repo.Create(attachment.ConvertToRepositoryAttachment()); err != nil {
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+func (s *AttachmentService) GetAttachments(taskID, userID, workspaceID string) ([]models.Attachment, error) {
+	if _, err := s.access.getTask(taskID, userID, workspaceID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	repoAttachments, err := s.repo.GetByTaskID(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := make([]models.Attachment, len(repoAttachments))
+	for i, repoAttachment := range repoAttachments {
+		attachments[i] = models.ConvertFromRepositoryAttachment(repoAttachment)
+	}
+
+	return attachments, nil
+}
+
+// OpenAttachment returns the attachment metadata with a reader over its
+// contents; the caller must close the reader.
+func (s *AttachmentService) OpenAttachment(taskID, id, userID, workspaceID string) (*models.Attachment, io.ReadCloser, error) {
+	if _, err := s.access.getTask(taskID, userID, workspaceID, models.RoleViewer); err != nil {
+		return nil, nil, err
+	}
+
+	repoAttachment, err := s.getAttachment(taskID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.store.Open(repoAttachment.Checksum)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil, errors.New("Attachment content not found")
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attachment := models.ConvertFromRepositoryAttachment(*repoAttachment)
+	return &attachment, content, nil
+}
+
+// DeleteAttachment lets either the uploader or a project manager remove an attachment.
+func (s *AttachmentService) DeleteAttachment(taskID, id, userID, workspaceID string) error {
+	repoAttachment, err := s.getAttachment(taskID, id)
+	if err != nil {
+		return err
+	}
+
+	required := models.RoleManager
+	if repoAttachment.UserID == userID {
+		required = models.RoleViewer
+	}
+
+	if _, err := s.access.getTask(taskID, userID, workspaceID, required); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		return err
+	}
+
+	return releaseBlobs(s.repo, s.store, []repository.Attachment{*repoAttachment})
+}
+
+func (s *AttachmentService) getAttachment(taskID, id string) (*repository.Attachment, error) {
+	repoAttachment, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if repoAttachment == nil || repoAttachment.TaskID != taskID {
+		return nil, errors.New("Attachment not found")
+	}
+
+	return repoAttachment, nil
+}
+
+// releaseBlobs deletes the blobs of removed attachments that no remaining
+// attachment refers to anymore.
+func releaseBlobs(repo repository.AttachmentRepository, store storage.BlobStore, attachments []repository.Attachment) error {
+	released := make(map[string]bool)
+	for _, attachment := range attachments {
+		if released[attachment.Checksum] {
+			continue
+		}
+		released[attachment.Checksum] = true
+
+		if err := releaseBlob(repo, store, attachment.Checksum); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func releaseBlob(repo repository.AttachmentRepository, store storage.BlobStore, checksum string) error {
+	unlock := lockBlob(checksum)
+	defer unlock()
+
+	count, err := repo.CountByChecksum(checksum)
+	if err != nil || count > 0 {
+		return err
+	}
+
+	return store.Delete(checksum)
+}
+
+// blobLocks serializes uploads and releases of the same blob, so a blob is not
+// deleted between an upload finding it and recording its attachment.
+var blobLocks [64]sync.Mutex
+
+func lockBlob(checksum string) func() {
+	hash := fnv.New32a()
+	hash.Write([]byte(checksum))
+
+	mu := &blobLocks[hash.Sum32()%uint32(len(blobLocks))]
+	mu.Lock()
+	return mu.Unlock
+}
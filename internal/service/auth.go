@@ -12,14 +12,16 @@ import (
 )
 
 type AuthService struct {
-	userRepo  repository.UserRepository
-	jwtSecret string
+	userRepo      repository.UserRepository
+	workspaceRepo repository.WorkspaceRepository
+	jwtSecret     string
 }
 
-func NewAuthService(userRepo repository.UserRepository, jwtSecret string) *AuthService {
+func NewAuthService(repo *repository.Repository, jwtSecret string) *AuthService {
 	return &AuthService{
-		userRepo:  userRepo,
-		jwtSecret: jwtSecret,
+		userRepo:      repo.User,
+		workspaceRepo: repo.Workspace,
+		jwtSecret:     jwtSecret,
 	}
 }
 
@@ -47,6 +49,16 @@ func (s *AuthService) Register(name, email, password string) (*models.UserRespon
 		return nil, err
 	}
 
+	workspace, err := createWorkspace(s.workspaceRepo, name+"'s workspace", user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	repoUser.WorkspaceID = workspace.ID
+	if err := s.userRepo.Update(repoUser); err != nil {
+		return nil, err
+	}
+
 	return &models.UserResponse{
 		ID:    user.ID,
 		Name:  user.Name,
@@ -54,6 +66,7 @@ func (s *AuthService) Register(name, email, password string) (*models.UserRespon
 	}, nil
 }
 
+// Login issues a token for the workspace the user last switched to.
 func (s *AuthService) Login(email, password string) (string, error) {
 	repoUser, err := s.userRepo.GetByEmail(email)
 	if err != nil || repoUser == nil {
@@ -65,10 +78,76 @@ func (s *AuthService) Login(email, password string) (string, error) {
 		return "", errors.New("Wrong email or password")
 	}
 
+	workspaceID, err := s.activeWorkspace(*repoUser)
+	if err != nil {
+		return "", err
+	}
+
+	return s.issueToken(*repoUser, workspaceID)
+}
+
+// SwitchWorkspace makes workspaceID the active workspace of the user and
+// returns a token scoped to it.
+func (s *AuthService) SwitchWorkspace(userID, workspaceID string) (string, error) {
+	repoUser, err := s.userRepo.GetByID(userID)
+	if err != nil || repoUser == nil {
+		return "", errors.New("User not found")
+	}
+
+	member, err := s.workspaceRepo.GetMember(workspaceID, userID)
+	if err != nil {
+		return "", err
+	}
+
+	if member == nil {
+		return "", errors.New("Workspace not found")
+	}
+
+	repoUser.WorkspaceID = workspaceID
+	if err := s.userRepo.Update(*repoUser); err != nil {
+		return "", err
+	}
+
+	return s.issueToken(*repoUser, workspaceID)
+}
+
+// activeWorkspace returns the last workspace the user switched to, falling
+// back to the first workspace they still belong to.
+func (s *AuthService) activeWorkspace(user repository.User) (string, error) {
+	if user.WorkspaceID != "" {
+		member, err := s.workspaceRepo.GetMember(user.WorkspaceID, user.ID)
+		if err != nil {
+			return "", err
+		}
+
+		if member != nil {
+			return user.WorkspaceID, nil
+		}
+	}
+
+	workspaces, err := s.workspaceRepo.GetByUserID(user.ID)
+	if err != nil {
+		return "", err
+	}
+
+	if len(workspaces) > 0 {
+		return workspaces[0].ID, nil
+	}
+
+	workspace, err := createWorkspace(s.workspaceRepo, user.Name+"'s workspace", user.ID)
+	if err != nil {
+		return "", err
+	}
+
+	return workspace.ID, nil
+}
+
+func (s *AuthService) issueToken(user repository.User, workspaceID string) (string, error) {
 	token := jwt.New(jwt.SigningMethodHS256)
 	claims := token.Claims.(jwt.MapClaims)
-	claims["user_id"] = repoUser.ID
-	claims["email"] = repoUser.Email
+	claims["user_id"] = user.ID
+	claims["email"] = user.Email
+	claims["workspace_id"] = workspaceID
 	claims["exp"] = time.Now().Add(time.Hour * 72).Unix()
 
 	tokenString, err := token.SignedString([]byte(s.jwtSecret))
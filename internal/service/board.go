@@ -0,0 +1,260 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"todo-api/internal/models"
+	"todo-api/internal/repository"
+)
+
+// Tasks are ordered within a column by a fractional position. A move takes
+// the midpoint between its new neighbours, so only the moved task is written.
+const (
+	boardGap = 1024.0
+	// minBoardGap is the smallest gap left between neighbours before the
+	// column is renumbered.
+	minBoardGap = 1e-6
+)
+
+// GetBoard groups the tasks of a project, or of the user's Inbox, by status.
+func (s *TaskService) GetBoard(query models.BoardQuery, userID, workspaceID string) (*models.Board, error) {
+	filter := repository.TaskFilter{
+		WorkspaceID:     workspaceID,
+		ExcludeArchived: true,
+		Sort:            []repository.SortKey{{Field: repository.SortByPosition}},
+	}
+
+	projectID := query.ProjectID
+	if projectID == "" || projectID == models.InboxProjectID {
+		projectID = ""
+		filter.VisibleTo = userID
+	} else if _, err := s.access.getProject(projectID, userID, workspaceID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+	filter.ProjectID = &projectID
+
+	w, err := loadWorkflow(s.workflowRepo, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	repoTasks, err := s.repo.List(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	board := models.Board{
+		ProjectID: projectID,
+		Columns:   make([]models.BoardColumn, len(w.statuses)),
+	}
+
+	columns := make(map[string]*models.BoardColumn, len(w.statuses))
+	for i, status := range w.statuses {
+		board.Columns[i] = models.BoardColumn{
+			WorkflowStatus: models.ConvertFromRepositoryWorkflowStatus(status),
+			Tasks:          []models.Task{},
+		}
+		columns[status.ID] = &board.Columns[i]
+	}
+
+	for _, repoTask := range repoTasks {
+		if column, ok := columns[repoTask.StatusID]; ok {
+			column.Tasks = append(column.Tasks, models.ConvertFromRepositoryTask(repoTask))
+		}
+	}
+
+	return &board, nil
+}
+
+// MoveTask places a task between two neighbours on the board, changing its
+// status when it lands in another column. Assignees may move their tasks.
+func (s *TaskService) MoveTask(id string, req models.MoveTaskRequest, userID, workspaceID string) (*models.Task, error) {
+	repoTask, err := s.access.getTask(id, userID, workspaceID, models.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	if repoTask.AssigneeID != userID {
+		if err := s.access.checkTask(*repoTask, userID, workspaceID, models.RoleEditor); err != nil {
+			return nil, err
+		}
+	}
+
+	w, err := loadWorkflow(s.workflowRepo, repoTask.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := string(req.Status)
+	if status == "" {
+		status = repoTask.Status
+	}
+
+	target := w.status(status)
+	if target == nil {
+		return nil, &WorkflowError{message: fmt.Sprintf("Invalid task status: %s", status)}
+	}
+
+	err = s.inTransaction(func(tx *TaskService) error {
+		repoTask, err = tx.moveTask(*repoTask, *target, req, userID, workspaceID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	task := models.ConvertFromRepositoryTask(*repoTask)
+	return &task, nil
+}
+
+// moveTask changes the status of a task to target when needed and places it
+// between its new neighbours in the target column.
+func (s *TaskService) moveTask(repoTask repository.Task, target repository.WorkflowStatus, req models.MoveTaskRequest, userID, workspaceID string) (*repository.Task, error) {
+	column, err := s.columnTasks(repoTask.ProjectID, repoTask.WorkspaceID, target.ID, repoTask.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := boardIndex(column, req.AfterID, req.BeforeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if target.ID != repoTask.StatusID {
+		statusReq := models.UpdateTaskRequest{Status: models.TaskStatus(target.Name)}
+		if _, err := s.UpdateTask(repoTask.ID, statusReq, userID, workspaceID); err != nil {
+			return nil, err
+		}
+
+		updated, err := s.repo.GetByID(repoTask.ID)
+		if err != nil {
+			return nil, err
+		}
+		repoTask = *updated
+	}
+
+	if repoTask.BoardPosition, err = s.positionAt(column, index); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(repoTask); err != nil {
+		return nil, err
+	}
+
+	return &repoTask, nil
+}
+
+// enterColumn puts a task at the bottom of a status column, enforcing the
+// column's work-in-progress limit.
+func (s *TaskService) enterColumn(task *models.Task, status repository.WorkflowStatus) error {
+	column, err := s.columnTasks(task.ProjectID, task.WorkspaceID, status.ID, task.ID)
+	if err != nil {
+		return err
+	}
+
+	if status.WIPLimit > 0 && len(column) >= status.WIPLimit {
+		return &WorkflowError{message: fmt.Sprintf("Column %s is at its WIP limit of %d", status.Name, status.WIPLimit)}
+	}
+
+	position, err := s.positionAt(column, len(column))
+	if err != nil {
+		return err
+	}
+
+	setTaskStatus(task, status)
+	task.Position = position
+	return nil
+}
+
+// columnTasks lists the tasks in one column of a board, leaving out excludeID.
+// Archived tasks are off the board.
+func (s *TaskService) columnTasks(projectID, workspaceID, statusID, excludeID string) ([]repository.Task, error) {
+	repoTasks, err := s.repo.List(repository.TaskFilter{
+		WorkspaceID:     workspaceID,
+		ProjectID:       &projectID,
+		StatusID:        statusID,
+		ExcludeArchived: true,
+		Sort:            []repository.SortKey{{Field: repository.SortByPosition}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	column := repoTasks[:0]
+	for _, repoTask := range repoTasks {
+		if repoTask.ID != excludeID {
+			column = append(column, repoTask)
+		}
+	}
+
+	return column, nil
+}
+
+// boardIndex finds the slot in a column between the tasks a move names as
+// its neighbours; without neighbours the task goes to the bottom.
+func boardIndex(column []repository.Task, afterID, beforeID string) (int, error) {
+	index := len(column)
+
+	if afterID != "" {
+		i := columnIndex(column, afterID)
+		if i < 0 {
+			return 0, errors.New("Task to move after is not in the target column")
+		}
+		index = i + 1
+	}
+
+	if beforeID != "" {
+		i := columnIndex(column, beforeID)
+		if i < 0 {
+			return 0, errors.New("Task to move before is not in the target column")
+		}
+		if afterID != "" && i != index {
+			return 0, errors.New("Neighbor tasks are not adjacent")
+		}
+		index = i
+	}
+
+	return index, nil
+}
+
+func columnIndex(column []repository.Task, id string) int {
+	for i, task := range column {
+		if task.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// positionAt returns the position for a task inserted at index of column.
+func (s *TaskService) positionAt(column []repository.Task, index int) (float64, error) {
+	switch {
+	case len(column) == 0:
+		return boardGap, nil
+	case index == 0:
+		return column[0].BoardPosition - boardGap, nil
+	case index == len(column):
+		return column[index-1].BoardPosition + boardGap, nil
+	}
+
+	if column[index].BoardPosition-column[index-1].BoardPosition < minBoardGap {
+		if err := s.rebalanceColumn(column); err != nil {
+			return 0, err
+		}
+	}
+
+	return (column[index-1].BoardPosition + column[index].BoardPosition) / 2, nil
+}
+
+// rebalanceColumn spreads the tasks of a column evenly again once repeated
+// moves have used up the gap between two of them.
+func (s *TaskService) rebalanceColumn(column []repository.Task) error {
+	for i := range column {
+		column[i].BoardPosition = float64(i+1) * boardGap
+		if err := s.repo.Update(column[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
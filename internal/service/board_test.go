@@ -0,0 +1,101 @@
+package service
+
+import (
+	"testing"
+	"todo-api/internal/repository"
+	"todo-api/internal/repository/memory"
+)
+
+func testColumn(positions ...float64) []repository.Task {
+	column := make([]repository.Task, len(positions))
+	for i, position := range positions {
+		column[i] = repository.Task{ID: string(rune('a' + i)), BoardPosition: position}
+	}
+	return column
+}
+
+func TestBoardIndex(t *testing.T) {
+	column := testColumn(1024, 2048, 3072)
+
+	tests := []struct {
+		name     string
+		afterID  string
+		beforeID string
+		want     int
+		wantErr  string
+	}{
+		{"no neighbours goes to the bottom", "", "", 3, ""},
+		{"after the first task", "a", "", 1, ""},
+		{"after the last task", "c", "", 3, ""},
+		{"before the first task", "", "a", 0, ""},
+		{"between adjacent tasks", "a", "b", 1, ""},
+		{"unknown after task", "x", "", 0, "Task to move after is not in the target column"},
+		{"unknown before task", "", "x", 0, "Task to move before is not in the target column"},
+		{"neighbours not adjacent", "a", "c", 0, "Neighbor tasks are not adjacent"},
+		{"neighbours in the wrong order", "b", "a", 0, "Neighbor tasks are not adjacent"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := boardIndex(column, tt.afterID, tt.beforeID)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("boardIndex() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("boardIndex() returned error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("boardIndex() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPositionAt(t *testing.T) {
+	tests := []struct {
+		name   string
+		column []repository.Task
+		index  int
+		want   float64
+	}{
+		{"empty column", testColumn(), 0, boardGap},
+		{"top of the column", testColumn(1024, 2048), 0, 0},
+		{"bottom of the column", testColumn(1024, 2048), 2, 3072},
+		{"between two tasks", testColumn(1024, 2048), 1, 1536},
+		{"renumbers a used up gap", testColumn(1024, 1024+minBoardGap/2, 2048), 1, 1536},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := memory.NewRepository()
+			for _, task := range tt.column {
+				if err := repo.Task.Create(task); err != nil {
+					t.Fatal(err)
+				}
+			}
+			s := NewTaskService(repo, nil, TaskOptions{})
+
+			got, err := s.positionAt(tt.column, tt.index)
+			if err != nil {
+				t.Fatalf("positionAt() returned error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("positionAt() = %v, want %v", got, tt.want)
+			}
+
+			for _, task := range tt.column {
+				stored, err := repo.Task.GetByID(task.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if stored.BoardPosition != task.BoardPosition {
+					t.Errorf("task %s stored at %v, want %v", task.ID, stored.BoardPosition, task.BoardPosition)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"todo-api/internal/models"
+	"todo-api/internal/repository"
+)
+
+// inTransaction runs fn with a TaskService whose changes are committed
+// together, or rolled back when fn returns an error.
+func (s *TaskService) inTransaction(fn func(tx *TaskService) error) error {
+	return s.transactor.InTransaction(func(repo *repository.Repository) error {
+		return fn(s.withRepository(repo))
+	})
+}
+
+// withRepository returns a TaskService with the same options working on repo.
+func (s *TaskService) withRepository(repo *repository.Repository) *TaskService {
+	return NewTaskService(repo, s.store, s.opts)
+}
+
+// BulkUpdateTasks applies the operations in order in a single transaction.
+// Every operation is authorized like its single-task endpoint, and the first
+// one that fails rolls back all of them.
+func (s *TaskService) BulkUpdateTasks(req models.BulkTaskRequest, userID, workspaceID string) (*models.BulkTaskResponse, error) {
+	response := models.BulkTaskResponse{Results: make([]models.BulkTaskResult, len(req.Operations))}
+	for i, op := range req.Operations {
+		response.Results[i] = models.BulkTaskResult{Index: i, Op: op.Op, TaskID: op.TaskID, Result: models.BulkSkipped}
+	}
+
+	failed := -1
+	err := s.inTransaction(func(tx *TaskService) error {
+		for i, op := range req.Operations {
+			task, err := tx.applyBulkOperation(op, userID, workspaceID)
+			if err != nil {
+				failed = i
+				response.Results[i].Result = models.BulkFailed
+				response.Results[i].Error = err.Error()
+				return err
+			}
+
+			response.Results[i].Result = models.BulkApplied
+			response.Results[i].Task = task
+		}
+		return nil
+	})
+
+	if err != nil && failed < 0 {
+		return nil, err
+	}
+
+	if failed >= 0 {
+		for i := 0; i < failed; i++ {
+			response.Results[i].Result = models.BulkRolledBack
+			response.Results[i].Task = nil
+		}
+		response.Error = fmt.Sprintf("Operation %d failed: %s", failed, response.Results[failed].Error)
+		return &response, nil
+	}
+
+	response.Applied = true
+	return &response, nil
+}
+
+func (s *TaskService) applyBulkOperation(op models.BulkTaskOperation, userID, workspaceID string) (*models.Task, error) {
+	switch op.Op {
+	case models.BulkUpdate:
+		if op.Fields == nil {
+			return nil, errors.New("Fields are required for update")
+		}
+		return s.UpdateTask(op.TaskID, *op.Fields, userID, workspaceID)
+	case models.BulkStatus:
+		if op.Status == "" {
+			return nil, errors.New("Status is required")
+		}
+		return s.TransitionTask(op.TaskID, op.Status, userID, workspaceID)
+	case models.BulkMove:
+		return s.UpdateTask(op.TaskID, models.UpdateTaskRequest{ProjectID: &op.ProjectID}, userID, workspaceID)
+	case models.BulkDelete:
+		return nil, s.DeleteTask(op.TaskID, userID, workspaceID)
+	default:
+		return nil, errors.New("Invalid operation, expected update, status, move or delete")
+	}
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"todo-api/internal/models"
+	"todo-api/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+const defaultCommentPageSize = 20
+
+type CommentService struct {
+	repo   repository.CommentRepository
+	access accessControl
+}
+
+func NewCommentService(repo *repository.Repository) *CommentService {
+	return &CommentService{
+		repo:   repo.Comment,
+		access: newAccessControl(repo),
+	}
+}
+
+func (s *CommentService) GetComments(taskID string, page models.PageQuery, userID, workspaceID string) (*models.CommentPage, error) {
+	if _, err := s.access.getTask(taskID, userID, workspaceID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	if page.Limit <= 0 {
+		page.Limit = defaultCommentPageSize
+	}
+
+	total, err := s.repo.CountByTaskID(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	repoComments, err := s.repo.GetByTaskID(taskID, page.Limit, page.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]models.Comment, len(repoComments))
+	for i, repoComment := range repoComments {
+		comments[i] = models.ConvertFromRepositoryComment(repoComment)
+	}
+
+	return &models.CommentPage{
+		Comments: comments,
+		Total:    total,
+		Limit:    page.Limit,
+		Offset:   page.Offset,
+	}, nil
+}
+
+func (s *CommentService) CreateComment(taskID, body, userID, workspaceID string) (*models.Comment, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, errors.New("Comment body is required")
+	}
+
+	if _, err := s.access.getTask(taskID, userID, workspaceID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	comment := models.Comment{
+		ID:        uuid.New().String(),
+		TaskID:    taskID,
+		AuthorID:  userID,
+		Body:      body,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err := s.repo.Create(comment.ConvertToRepositoryComment())
+	if err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+func (s *CommentService) UpdateComment(taskID, id, body, userID, workspaceID string) (*models.Comment, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, errors.New("Comment body is required")
+	}
+
+	if _, err := s.access.getTask(taskID, userID, workspaceID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	repoComment, err := s.getComment(taskID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if repoComment.UserID != userID {
+		return nil, errors.New("Only the author can edit a comment")
+	}
+
+	comment := models.ConvertFromRepositoryComment(*repoComment)
+	if comment.Body != body {
+		comment.Body = body
+		comment.Edited = true
+		comment.UpdatedAt = time.Now().UTC()
+	}
+
+	err = s.repo.Update(comment.ConvertToRepositoryComment())
+	if err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+// DeleteComment lets either the author or a project manager remove a comment.
+func (s *CommentService) DeleteComment(taskID, id, userID, workspaceID string) error {
+	repoComment, err := s.getComment(taskID, id)
+	if err != nil {
+		return err
+	}
+
+	required := models.RoleManager
+	if repoComment.UserID == userID {
+		required = models.RoleViewer
+	}
+
+	if _, err := s.access.getTask(taskID, userID, workspaceID, required); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(id)
+}
+
+func (s *CommentService) getComment(taskID, id string) (*repository.Comment, error) {
+	repoComment, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if repoComment == nil || repoComment.TaskID != taskID {
+		return nil, errors.New("Comment not found")
+	}
+
+	return repoComment, nil
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+	"todo-api/internal/repository"
+)
+
+const defaultTaskPageSize = 50
+
+// taskCursor holds the sort values of the last task on a page. Sort records
+// the sort the page was listed with, since the values only make sense for it.
+type taskCursor struct {
+	Sort           string     `json:"s,omitempty"`
+	ID             string     `json:"id"`
+	CreatedAt      time.Time  `json:"c"`
+	DueAt          *time.Time `json:"d,omitempty"`
+	Priority       int        `json:"p,omitempty"`
+	Title          string     `json:"t,omitempty"`
+	StatusPosition int        `json:"sp,omitempty"`
+	BoardPosition  float64    `json:"bp,omitempty"`
+	CompletedAt    *time.Time `json:"ca,omitempty"`
+}
+
+func encodeTaskCursor(task repository.Task, sort string) string {
+	data, _ := json.Marshal(taskCursor{
+		Sort:           sort,
+		ID:             task.ID,
+		CreatedAt:      task.CreatedAt,
+		DueAt:          task.DueAt,
+		Priority:       task.Priority,
+		Title:          task.Title,
+		StatusPosition: task.StatusPosition,
+		BoardPosition:  task.BoardPosition,
+		CompletedAt:    task.CompletedAt,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeTaskCursor(value, sort string) (*repository.Task, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.New("Invalid cursor")
+	}
+
+	var cursor taskCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" {
+		return nil, errors.New("Invalid cursor")
+	}
+
+	if cursor.Sort != sort {
+		return nil, errors.New("Cursor does not match the requested sort")
+	}
+
+	return &repository.Task{
+		ID:             cursor.ID,
+		CreatedAt:      cursor.CreatedAt,
+		DueAt:          cursor.DueAt,
+		Priority:       cursor.Priority,
+		Title:          cursor.Title,
+		StatusPosition: cursor.StatusPosition,
+		BoardPosition:  cursor.BoardPosition,
+		CompletedAt:    cursor.CompletedAt,
+	}, nil
+}
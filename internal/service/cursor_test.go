@@ -0,0 +1,81 @@
+package service
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+	"todo-api/internal/repository"
+)
+
+func TestTaskCursorRoundTrip(t *testing.T) {
+	created := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	due := created.Add(48 * time.Hour)
+
+	tests := []struct {
+		name string
+		task repository.Task
+		sort string
+	}{
+		{"default sort", repository.Task{ID: "a", CreatedAt: created}, ""},
+		{"due date", repository.Task{ID: "b", CreatedAt: created, DueAt: &due}, "due"},
+		{"without due date", repository.Task{ID: "c", CreatedAt: created}, "due,-priority"},
+		{"every sort value", repository.Task{
+			ID:             "d",
+			CreatedAt:      created,
+			DueAt:          &due,
+			Priority:       3,
+			Title:          "Write report",
+			StatusPosition: 2,
+			BoardPosition:  1536.5,
+			CompletedAt:    &created,
+		}, "title,-completed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeTaskCursor(encodeTaskCursor(tt.task, tt.sort), tt.sort)
+			if err != nil {
+				t.Fatalf("decodeTaskCursor() returned error: %v", err)
+			}
+
+			if got.ID != tt.task.ID || !got.CreatedAt.Equal(tt.task.CreatedAt) ||
+				!equalTimes(got.DueAt, tt.task.DueAt) || !equalTimes(got.CompletedAt, tt.task.CompletedAt) ||
+				got.Priority != tt.task.Priority || got.Title != tt.task.Title ||
+				got.StatusPosition != tt.task.StatusPosition || got.BoardPosition != tt.task.BoardPosition {
+				t.Errorf("decodeTaskCursor() = %+v, want %+v", *got, tt.task)
+			}
+		})
+	}
+}
+
+func TestDecodeTaskCursorErrors(t *testing.T) {
+	valid := encodeTaskCursor(repository.Task{ID: "a", CreatedAt: time.Now()}, "priority")
+
+	tests := []struct {
+		name    string
+		value   string
+		sort    string
+		wantErr string
+	}{
+		{"not base64", "%%%", "", "Invalid cursor"},
+		{"not json", base64.RawURLEncoding.EncodeToString([]byte("nope")), "", "Invalid cursor"},
+		{"missing id", base64.RawURLEncoding.EncodeToString([]byte(`{"c":"2026-03-01T12:00:00Z"}`)), "", "Invalid cursor"},
+		{"other sort", valid, "-priority", "Cursor does not match the requested sort"},
+		{"default sort", valid, "", "Cursor does not match the requested sort"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeTaskCursor(tt.value, tt.sort); err == nil || err.Error() != tt.wantErr {
+				t.Errorf("decodeTaskCursor() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func equalTimes(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"errors"
+	"todo-api/internal/models"
+)
+
+func (s *TaskService) AddDependency(taskID, dependsOnID, userID, workspaceID string) (*models.Task, error) {
+	if taskID == dependsOnID {
+		return nil, errors.New("Task cannot depend on itself")
+	}
+
+	if err := s.checkDependencyAccess(taskID, dependsOnID, userID, workspaceID); err != nil {
+		return nil, err
+	}
+
+	createsCycle, err := s.dependsOn(dependsOnID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if createsCycle {
+		return nil, errors.New("Dependency would create a cycle")
+	}
+
+	err = s.trackChanges(taskID, userID, func() error {
+		return s.repo.AddDependency(taskID, dependsOnID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetTask(taskID, userID, workspaceID)
+}
+
+func (s *TaskService) RemoveDependency(taskID, dependsOnID, userID, workspaceID string) (*models.Task, error) {
+	if err := s.checkDependencyAccess(taskID, dependsOnID, userID, workspaceID); err != nil {
+		return nil, err
+	}
+
+	err := s.trackChanges(taskID, userID, func() error {
+		return s.repo.RemoveDependency(taskID, dependsOnID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetTask(taskID, userID, workspaceID)
+}
+
+func (s *TaskService) checkDependencyAccess(taskID, dependsOnID, userID, workspaceID string) error {
+	for _, id := range []string{taskID, dependsOnID} {
+		if _, err := s.access.getTask(id, userID, workspaceID, models.RoleEditor); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dependsOn reports whether taskID transitively depends on targetID. The walk
+// follows dependencies on trashed tasks too, since restoring them brings the
+// edges back.
+func (s *TaskService) dependsOn(taskID, targetID string) (bool, error) {
+	visited := map[string]bool{taskID: true}
+	queue := []string{taskID}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current == targetID {
+			return true, nil
+		}
+
+		dependsOnIDs, err := s.repo.GetDependencyIDs(current)
+		if err != nil {
+			return false, err
+		}
+
+		for _, next := range dependsOnIDs {
+			if !visited[next] {
+				visited[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func (s *TaskService) checkDependenciesCompleted(taskID string) error {
+	prerequisites, err := s.repo.GetDependencies(taskID)
+	if err != nil {
+		return err
+	}
+
+	for _, prerequisite := range prerequisites {
+		if !prerequisite.Done {
+			return errors.New("Task is blocked by unfinished tasks")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"todo-api/internal/models"
+	"todo-api/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// historyFields lists the task fields tracked in the history, in the order
+// their changes are reported. Board positions are left out on purpose.
+var historyFields = []struct {
+	name  string
+	value func(task *models.Task) string
+}{
+	{"title", func(t *models.Task) string { return t.Title }},
+	{"description", func(t *models.Task) string { return t.Description }},
+	{"status", func(t *models.Task) string { return string(t.Status) }},
+	{"priority", func(t *models.Task) string { return string(t.Priority) }},
+	{"due_date", func(t *models.Task) string { return t.DueDate }},
+	{"due_time", func(t *models.Task) string { return t.DueTime }},
+	{"due_timezone", func(t *models.Task) string { return t.DueTimezone }},
+	{"recurrence", func(t *models.Task) string { return t.Recurrence }},
+	{"estimate", func(t *models.Task) string { return formatEstimate(t.Estimate) }},
+	{"project_id", func(t *models.Task) string { return t.ProjectID }},
+	{"parent_id", func(t *models.Task) string { return t.ParentID }},
+	{"assignee_id", func(t *models.Task) string { return t.AssigneeID }},
+	{"tags", func(t *models.Task) string { return strings.Join(t.Tags, ", ") }},
+	{"blocked_by", func(t *models.Task) string { return strings.Join(t.BlockedBy, ", ") }},
+	{"archived", func(t *models.Task) string { return strconv.FormatBool(t.Archived) }},
+}
+
+// GetHistory lists the changes of a task. Tasks in the trash keep their
+// history readable, since that is where it tells who deleted them.
+func (s *TaskService) GetHistory(id, userID, workspaceID string) ([]models.TaskHistoryEntry, error) {
+	repoTask, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if repoTask == nil {
+		if repoTask, err = s.repo.GetTrashed(id); err != nil {
+			return nil, err
+		}
+	}
+
+	if repoTask == nil {
+		return nil, errors.New("Task not found")
+	}
+
+	if err := s.access.checkTask(*repoTask, userID, workspaceID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	repoEntries, err := s.historyRepo.GetByTaskID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]models.TaskHistoryEntry, len(repoEntries))
+	for i, repoEntry := range repoEntries {
+		entries[i] = models.ConvertFromRepositoryHistoryEntry(repoEntry)
+	}
+
+	return entries, nil
+}
+
+// recordHistory stores the fields that differ between two versions of a
+// task. before is nil for a created task and after is nil for a deleted one;
+// updates that change no tracked field are not recorded.
+func (s *TaskService) recordHistory(before, after *models.Task, userID string) error {
+	entry := models.TaskHistoryEntry{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Action:    models.HistoryActionUpdated,
+		Changes:   []models.FieldChange{},
+		CreatedAt: time.Now().UTC(),
+	}
+
+	switch {
+	case before == nil:
+		entry.TaskID, entry.Action = after.ID, models.HistoryActionCreated
+	case after == nil:
+		entry.TaskID, entry.Action = before.ID, models.HistoryActionDeleted
+	default:
+		entry.TaskID = after.ID
+	}
+
+	for _, field := range historyFields {
+		var oldValue, newValue string
+		if before != nil {
+			oldValue = field.value(before)
+		}
+		if after != nil {
+			newValue = field.value(after)
+		}
+
+		if oldValue != newValue {
+			entry.Changes = append(entry.Changes, models.FieldChange{Field: field.name, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+
+	if entry.Action == models.HistoryActionUpdated && len(entry.Changes) == 0 {
+		return nil
+	}
+
+	return s.historyRepo.Create(entry.ConvertToRepositoryHistoryEntry())
+}
+
+// recordEvent stores an entry that changes no fields, such as a restore.
+func (s *TaskService) recordEvent(taskID, action, userID string) error {
+	entry := models.TaskHistoryEntry{
+		ID:        uuid.New().String(),
+		TaskID:    taskID,
+		UserID:    userID,
+		Action:    action,
+		Changes:   []models.FieldChange{},
+		CreatedAt: time.Now().UTC(),
+	}
+
+	return s.historyRepo.Create(entry.ConvertToRepositoryHistoryEntry())
+}
+
+// recordChanges compares tasks as they were before a bulk change with their
+// current state and records the difference for each of them.
+func (s *TaskService) recordChanges(before []repository.Task, userID string) error {
+	for _, repoTask := range before {
+		current, err := s.repo.GetByID(repoTask.ID)
+		if err != nil {
+			return err
+		}
+
+		old := models.ConvertFromRepositoryTask(repoTask)
+		if current == nil {
+			if err := s.recordHistory(&old, nil, userID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		updated := models.ConvertFromRepositoryTask(*current)
+		if err := s.recordHistory(&old, &updated, userID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// trackChanges runs change and records how it altered the task with id.
+func (s *TaskService) trackChanges(id, userID string, change func() error) error {
+	before, err := s.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := change(); err != nil {
+		return err
+	}
+
+	if before == nil {
+		return nil
+	}
+
+	return s.recordChanges([]repository.Task{*before}, userID)
+}
@@ -0,0 +1,496 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"todo-api/internal/models"
+	"todo-api/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type ProjectService struct {
+	repo          repository.ProjectRepository
+	taskRepo      repository.TaskRepository
+	userRepo      repository.UserRepository
+	workspaceRepo repository.WorkspaceRepository
+	workflowRepo  repository.WorkflowRepository
+	transactor    repository.Transactor
+	access        accessControl
+	taskService   *TaskService
+}
+
+func NewProjectService(repo *repository.Repository, taskService *TaskService) *ProjectService {
+	return &ProjectService{
+		repo:          repo.Project,
+		taskRepo:      repo.Task,
+		userRepo:      repo.User,
+		workspaceRepo: repo.Workspace,
+		workflowRepo:  repo.Workflow,
+		transactor:    repo.Transactor,
+		access:        newAccessControl(repo),
+		taskService:   taskService,
+	}
+}
+
+// inTransaction runs fn with a ProjectService whose changes, including those
+// made through its task service, are committed together.
+func (s *ProjectService) inTransaction(fn func(tx *ProjectService) error) error {
+	return s.transactor.InTransaction(func(repo *repository.Repository) error {
+		return fn(NewProjectService(repo, s.taskService.withRepository(repo)))
+	})
+}
+
+func (s *ProjectService) CreateProject(req models.CreateProjectRequest, userID, workspaceID string) (*models.Project, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("Project name is required")
+	}
+
+	if req.EstimateUnit == "" {
+		req.EstimateUnit = models.EstimateHours
+	}
+
+	if !req.EstimateUnit.IsValid() {
+		return nil, errors.New("Invalid estimate unit, expected hours or points")
+	}
+
+	project := models.Project{
+		ID:           uuid.New().String(),
+		Name:         name,
+		Description:  req.Description,
+		UserID:       userID,
+		WorkspaceID:  workspaceID,
+		EstimateUnit: req.EstimateUnit,
+		CreatedAt:    time.Now().UTC(),
+	}
+
+	err := s.repo.Create(project.ConvertToRepositoryProject())
+	if err != nil {
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+func (s *ProjectService) GetProjects(userID, workspaceID string) ([]models.Project, error) {
+	repoProjects, err := s.repo.GetByUserID(userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	projects := make([]models.Project, len(repoProjects))
+	for i, repoProject := range repoProjects {
+		projects[i] = models.ConvertFromRepositoryProject(repoProject)
+	}
+
+	return projects, nil
+}
+
+// GetSharedProjects lists the projects other users have shared with userID.
+func (s *ProjectService) GetSharedProjects(userID, workspaceID string) ([]models.SharedProject, error) {
+	repoProjects, err := s.repo.GetSharedWithUser(userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	projects := make([]models.SharedProject, len(repoProjects))
+	for i, repoProject := range repoProjects {
+		role, err := s.access.projectRole(repoProject, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		projects[i] = models.SharedProject{
+			Project: models.ConvertFromRepositoryProject(repoProject),
+			Role:    role,
+		}
+	}
+
+	return projects, nil
+}
+
+func (s *ProjectService) GetProject(id, userID, workspaceID string) (*models.Project, error) {
+	repoProject, err := s.access.getProject(id, userID, workspaceID, models.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	project := models.ConvertFromRepositoryProject(*repoProject)
+	return &project, nil
+}
+
+func (s *ProjectService) UpdateProject(id string, req models.UpdateProjectRequest, userID, workspaceID string) (*models.Project, error) {
+	repoProject, err := s.access.getProject(id, userID, workspaceID, models.RoleManager)
+	if err != nil {
+		return nil, err
+	}
+
+	project := models.ConvertFromRepositoryProject(*repoProject)
+
+	if name := strings.TrimSpace(req.Name); name != "" {
+		project.Name = name
+	}
+
+	if req.Description != "" {
+		project.Description = req.Description
+	}
+
+	if req.EstimateUnit != "" {
+		if !req.EstimateUnit.IsValid() {
+			return nil, errors.New("Invalid estimate unit, expected hours or points")
+		}
+		project.EstimateUnit = req.EstimateUnit
+	}
+
+	err = s.repo.Update(project.ConvertToRepositoryProject())
+	if err != nil {
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+// DeleteProject removes a project together with its tasks (cascade) or after
+// moving them back to the Inbox (inbox, the default).
+func (s *ProjectService) DeleteProject(id, mode, userID, workspaceID string) error {
+	if _, err := s.access.getProject(id, userID, workspaceID, models.RoleOwner); err != nil {
+		return err
+	}
+
+	// Blobs cannot be rolled back, so they are only released after commit.
+	var removed []repository.Attachment
+	err := s.inTransaction(func(tx *ProjectService) error {
+		var err error
+		removed, err = tx.deleteProject(id, mode, userID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return releaseBlobs(s.taskService.attachmentRepo, s.taskService.store, removed)
+}
+
+// deleteProject removes a project and returns the attachments removed with
+// its tasks.
+func (s *ProjectService) deleteProject(id, mode, userID string) ([]repository.Attachment, error) {
+	var removed []repository.Attachment
+	switch mode {
+	case models.ProjectDeleteCascade:
+		attachments, err := s.taskService.deleteProjectTasks(id, userID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.resetWorkflow(id); err != nil {
+			return nil, err
+		}
+		removed = attachments
+	case "", models.ProjectDeleteInbox:
+		moved, err := s.taskRepo.List(repository.TaskFilter{ProjectID: &id})
+		if err != nil {
+			return nil, err
+		}
+		if err := s.resetWorkflow(id); err != nil {
+			return nil, err
+		}
+		if err := s.taskRepo.MoveProjectTasks(id, ""); err != nil {
+			return nil, err
+		}
+		if err := s.taskService.recordChanges(moved, userID); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("Invalid delete mode, expected cascade or inbox")
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		return nil, err
+	}
+
+	return removed, nil
+}
+
+func (s *ProjectService) GetWorkflow(id, userID, workspaceID string) (*models.Workflow, error) {
+	if _, err := s.access.getProject(id, userID, workspaceID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	w, err := loadWorkflow(s.workflowRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	workflow := w.model()
+	return &workflow, nil
+}
+
+// UpdateWorkflow replaces the workflow of a project. Statuses keep their
+// identity by name; tasks in statuses that are dropped move to the status
+// of the same name, the first done status or the initial one.
+func (s *ProjectService) UpdateWorkflow(id string, req models.UpdateWorkflowRequest, userID, workspaceID string) (*models.Workflow, error) {
+	if _, err := s.access.getProject(id, userID, workspaceID, models.RoleManager); err != nil {
+		return nil, err
+	}
+
+	if len(req.Statuses) == 0 {
+		return nil, &WorkflowError{message: "Workflow needs at least one status"}
+	}
+
+	current, err := loadWorkflow(s.workflowRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]repository.WorkflowStatus, len(req.Statuses))
+	statusIDs := make(map[string]string)
+	hasDone := false
+	for i, reqStatus := range req.Statuses {
+		name := strings.TrimSpace(reqStatus.Name)
+		if name == "" {
+			return nil, &WorkflowError{message: "Status name is required"}
+		}
+
+		key := strings.ToLower(name)
+		if _, exists := statusIDs[key]; exists {
+			return nil, &WorkflowError{message: "Duplicate status: " + name}
+		}
+
+		status := repository.WorkflowStatus{
+			ID:        uuid.New().String(),
+			ProjectID: id,
+			Name:      name,
+			Position:  i,
+			Done:      reqStatus.Done,
+			WIPLimit:  reqStatus.WIPLimit,
+		}
+
+		if existing := current.status(name); existing != nil && existing.ProjectID == id {
+			status.ID = existing.ID
+		}
+
+		statuses[i] = status
+		statusIDs[key] = status.ID
+		hasDone = hasDone || status.Done
+	}
+
+	if !hasDone {
+		return nil, &WorkflowError{message: "Workflow needs at least one done status"}
+	}
+
+	var transitions []repository.WorkflowTransition
+	seen := make(map[repository.WorkflowTransition]bool)
+	for _, reqTransition := range req.Transitions {
+		fromID, ok := statusIDs[strings.ToLower(strings.TrimSpace(string(reqTransition.From)))]
+		if !ok {
+			return nil, &WorkflowError{message: "Unknown status in transition: " + string(reqTransition.From)}
+		}
+
+		toID, ok := statusIDs[strings.ToLower(strings.TrimSpace(string(reqTransition.To)))]
+		if !ok {
+			return nil, &WorkflowError{message: "Unknown status in transition: " + string(reqTransition.To)}
+		}
+
+		if fromID == toID {
+			return nil, &WorkflowError{message: "Transition must change the status"}
+		}
+
+		transition := repository.WorkflowTransition{FromStatusID: fromID, ToStatusID: toID}
+		if !seen[transition] {
+			seen[transition] = true
+			transitions = append(transitions, transition)
+		}
+	}
+
+	updated := newWorkflow(id, statuses, transitions)
+	err = s.inTransaction(func(tx *ProjectService) error {
+		tasks, err := tx.taskRepo.List(repository.TaskFilter{ProjectID: &id})
+		if err != nil {
+			return err
+		}
+
+		if err := tx.workflowRepo.SaveWorkflow(id, statuses, transitions); err != nil {
+			return err
+		}
+
+		if err := tx.replaceStatuses(id, current, updated); err != nil {
+			return err
+		}
+
+		return tx.taskService.recordChanges(tasks, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	workflow := updated.model()
+	return &workflow, nil
+}
+
+// resetWorkflow moves the tasks of a project back to the default workflow and
+// removes the project's own statuses.
+func (s *ProjectService) resetWorkflow(id string) error {
+	current, err := loadWorkflow(s.workflowRepo, id)
+	if err != nil {
+		return err
+	}
+
+	if current.projectID != id {
+		return nil
+	}
+
+	defaults, err := loadWorkflow(s.workflowRepo, "")
+	if err != nil {
+		return err
+	}
+
+	return s.replaceStatuses(id, current, defaults)
+}
+
+// replaceStatuses moves the tasks of a project off the statuses of from that
+// are not part of to, deleting those statuses when they belonged to the project.
+// Tasks in a kept status that became done or open have their completion reset.
+func (s *ProjectService) replaceStatuses(id string, from, to *workflow) error {
+	kept := make(map[string]repository.WorkflowStatus)
+	for _, status := range to.statuses {
+		kept[status.ID] = status
+	}
+
+	for _, status := range from.statuses {
+		if next, ok := kept[status.ID]; ok {
+			if next.Done != status.Done {
+				if err := s.taskRepo.ReplaceStatus(id, status.ID, status.ID); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if err := s.taskRepo.ReplaceStatus(id, status.ID, to.mapStatus(status.Name, status.Done).ID); err != nil {
+			return err
+		}
+
+		if status.ProjectID == id {
+			if err := s.workflowRepo.DeleteStatus(status.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *ProjectService) GetMembers(id, userID, workspaceID string) ([]models.ProjectMember, error) {
+	if _, err := s.access.getProject(id, userID, workspaceID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	repoMembers, err := s.repo.GetMembers(id)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]models.ProjectMember, len(repoMembers))
+	for i, repoMember := range repoMembers {
+		members[i] = models.ConvertFromRepositoryProjectMember(repoMember)
+	}
+
+	return members, nil
+}
+
+// AddMember invites a registered user to a project, or changes their role
+// when they are already a member.
+func (s *ProjectService) AddMember(id string, req models.AddMemberRequest, userID, workspaceID string) (*models.ProjectMember, error) {
+	repoProject, err := s.access.getProject(id, userID, workspaceID, models.RoleManager)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByEmail(req.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, errors.New("User not found")
+	}
+
+	if err := checkWorkspaceMember(s.workspaceRepo, workspaceID, user.ID); err != nil {
+		return nil, err
+	}
+
+	if user.ID == repoProject.UserID {
+		return nil, errors.New("User already owns the project")
+	}
+
+	return s.saveMember(id, user.ID, req.Role)
+}
+
+func (s *ProjectService) UpdateMember(id, memberID string, req models.UpdateMemberRequest, userID, workspaceID string) (*models.ProjectMember, error) {
+	if _, err := s.access.getProject(id, userID, workspaceID, models.RoleManager); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkMemberExists(id, memberID); err != nil {
+		return nil, err
+	}
+
+	return s.saveMember(id, memberID, req.Role)
+}
+
+// RemoveMember revokes a membership; managers may remove anyone and members may leave on their own.
+func (s *ProjectService) RemoveMember(id, memberID, userID, workspaceID string) error {
+	required := models.RoleManager
+	if memberID == userID {
+		required = models.RoleViewer
+	}
+
+	if _, err := s.access.getProject(id, userID, workspaceID, required); err != nil {
+		return err
+	}
+
+	if err := s.checkMemberExists(id, memberID); err != nil {
+		return err
+	}
+
+	return s.repo.RemoveMember(id, memberID)
+}
+
+func (s *ProjectService) saveMember(id, memberID string, role models.ProjectRole) (*models.ProjectMember, error) {
+	if !role.IsValid() {
+		return nil, errors.New("Invalid role, expected viewer, editor or manager")
+	}
+
+	member := models.ProjectMember{
+		ProjectID: id,
+		UserID:    memberID,
+		Role:      role,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err := s.repo.SaveMember(member.ConvertToRepositoryProjectMember()); err != nil {
+		return nil, err
+	}
+
+	repoMember, err := s.repo.GetMember(id, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	member = models.ConvertFromRepositoryProjectMember(*repoMember)
+	return &member, nil
+}
+
+func (s *ProjectService) checkMemberExists(id, memberID string) error {
+	member, err := s.repo.GetMember(id, memberID)
+	if err != nil {
+		return err
+	}
+
+	if member == nil {
+		return errors.New("Member not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"errors"
+	"time"
+	"todo-api/internal/models"
+	"todo-api/internal/recurrence"
+
+	"github.com/google/uuid"
+)
+
+const defaultOccurrenceCount = 5
+
+func (s *TaskService) GetOccurrences(id string, count int, userID, workspaceID string) ([]models.Occurrence, error) {
+	task, err := s.GetTask(id, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.Recurrence == "" {
+		return nil, errors.New("Task is not recurring")
+	}
+
+	rule, err := recurrence.Parse(task.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	if count <= 0 {
+		count = defaultOccurrenceCount
+	}
+
+	loc := models.DueLocation(task.DueTimezone)
+	dueTimes := rule.Occurrences(task.DueAt.In(loc), count)
+
+	occurrences := make([]models.Occurrence, len(dueTimes))
+	for i, dueAt := range dueTimes {
+		occurrences[i] = models.Occurrence{
+			DueDate: dueAt.Format(models.DueDateLayout),
+			DueAt:   dueAt,
+		}
+		if task.DueTime != "" {
+			occurrences[i].DueTime = dueAt.Format(models.DueTimeLayout)
+		}
+	}
+
+	return occurrences, nil
+}
+
+// spawnNextOccurrence creates the task that follows a finished occurrence of a
+// recurring series. It returns nil when the series has ended.
+func (s *TaskService) spawnNextOccurrence(finished models.Task, rrule, userID string) (*models.Task, error) {
+	rule, err := recurrence.Parse(rrule)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := models.DueLocation(finished.DueTimezone)
+	dueAt, ok := rule.Next(finished.DueAt.In(loc))
+	if !ok {
+		return nil, nil
+	}
+
+	w, err := loadWorkflow(s.workflowRepo, finished.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The next occurrence always gets a card, whatever the column's WIP limit.
+	column, err := s.columnTasks(finished.ProjectID, finished.WorkspaceID, w.initial().ID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	next := finished
+	setTaskStatus(&next, w.initial())
+	if next.Position, err = s.positionAt(column, len(column)); err != nil {
+		return nil, err
+	}
+
+	repoTask := next.ConvertToRepositoryTask()
+	repoTask.ID = uuid.New().String()
+	repoTask.CreatedAt = time.Now().UTC()
+	repoTask.DueAt = &dueAt
+	repoTask.Recurrence = rule.Advance().String()
+
+	if err := s.repo.Create(repoTask); err != nil {
+		return nil, err
+	}
+
+	tags, err := s.repo.GetTags(finished.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tag := range tags {
+		if err := s.repo.AddTag(repoTask.ID, tag.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	created, err := s.repo.GetByID(repoTask.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	next = models.ConvertFromRepositoryTask(*created)
+	if err := s.recordHistory(nil, &next, userID); err != nil {
+		return nil, err
+	}
+
+	return &next, nil
+}
+
+func setTaskRecurrence(task *models.Task, rrule string) error {
+	if rrule == "" {
+		task.Recurrence = ""
+		return nil
+	}
+
+	if task.DueAt == nil {
+		return errors.New("Recurring tasks require a due date")
+	}
+
+	rule, err := recurrence.Parse(rrule)
+	if err != nil {
+		return errors.New("Invalid recurrence rule: " + err.Error())
+	}
+
+	task.Recurrence = rule.String()
+	return nil
+}
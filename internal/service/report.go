@@ -0,0 +1,239 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+	"todo-api/internal/models"
+	"todo-api/internal/repository"
+)
+
+type ReportService struct {
+	taskRepo    repository.TaskRepository
+	projectRepo repository.ProjectRepository
+	timeRepo    repository.TimeEntryRepository
+	access      accessControl
+}
+
+func NewReportService(repo *repository.Repository) *ReportService {
+	return &ReportService{
+		taskRepo:    repo.Task,
+		projectRepo: repo.Project,
+		timeRepo:    repo.TimeEntry,
+		access:      newAccessControl(repo),
+	}
+}
+
+// GetEstimateReport compares estimates with actual time for the estimated
+// tasks userID can see, grouped by the user who did the work and the week
+// the task was finished in. Work is credited to the assignee, or to the
+// creator of unassigned tasks.
+func (s *ReportService) GetEstimateReport(query models.EstimateReportQuery, userID, workspaceID string) (*models.EstimateReport, error) {
+	var from, to *time.Time
+
+	if query.From != "" {
+		bound, err := parseDueBound(query.From)
+		if err != nil {
+			return nil, errors.New("Invalid from value")
+		}
+		from = &bound
+	}
+
+	if query.To != "" {
+		bound, err := parseDueBound(query.To)
+		if err != nil {
+			return nil, errors.New("Invalid to value")
+		}
+		to = &bound
+	}
+
+	if query.UserID == models.AssigneeMe {
+		query.UserID = userID
+	}
+
+	visibleProjectIDs, err := s.access.visibleProjectIDs(userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := repository.TaskFilter{
+		WorkspaceID:       workspaceID,
+		VisibleTo:         userID,
+		VisibleProjectIDs: visibleProjectIDs,
+	}
+
+	switch query.ProjectID {
+	case "":
+	case models.InboxProjectID:
+		filter.ProjectID = new(string)
+	default:
+		filter.ProjectID = &query.ProjectID
+	}
+
+	repoTasks, err := s.taskRepo.List(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var finished []repository.Task
+	for _, repoTask := range repoTasks {
+		if !repoTask.Done || repoTask.CompletedAt == nil || repoTask.Estimate == nil {
+			continue
+		}
+		if from != nil && repoTask.CompletedAt.Before(*from) {
+			continue
+		}
+		if to != nil && !repoTask.CompletedAt.Before(*to) {
+			continue
+		}
+		if query.UserID != "" && taskWorker(repoTask) != query.UserID {
+			continue
+		}
+		finished = append(finished, repoTask)
+	}
+
+	tracked, err := s.trackedTime(finished)
+	if err != nil {
+		return nil, err
+	}
+
+	units := make(map[string]models.EstimateUnit)
+	rows := make(map[string]*models.EstimateReportRow)
+	report := &models.EstimateReport{Rows: []models.EstimateReportRow{}}
+	for _, repoTask := range finished {
+		unit, err := s.estimateUnit(repoTask.ProjectID, units)
+		if err != nil {
+			return nil, err
+		}
+
+		worker := taskWorker(repoTask)
+		week := weekStart(*repoTask.CompletedAt).Format(models.DueDateLayout)
+		key := worker + "|" + week + "|" + string(unit)
+
+		row := rows[key]
+		if row == nil {
+			row = &models.EstimateReportRow{UserID: worker, Week: week, Unit: unit}
+			rows[key] = row
+		}
+
+		actual, ok := tracked[repoTask.ID]
+		if ok {
+			row.TrackedTasks++
+		} else {
+			actual = repoTask.CompletedAt.Sub(repoTask.CreatedAt)
+		}
+
+		row.Tasks++
+		row.Estimate += *repoTask.Estimate
+		row.ActualHours += actual.Hours()
+	}
+
+	for _, row := range rows {
+		if row.Estimate > 0 {
+			row.HoursPerUnit = roundHundredths(row.ActualHours / row.Estimate)
+		}
+		row.Estimate = roundHundredths(row.Estimate)
+		row.ActualHours = roundHundredths(row.ActualHours)
+		report.Rows = append(report.Rows, *row)
+	}
+
+	sort.Slice(report.Rows, func(i, j int) bool {
+		a, b := report.Rows[i], report.Rows[j]
+		if a.Week != b.Week {
+			return a.Week < b.Week
+		}
+		if a.UserID != b.UserID {
+			return a.UserID < b.UserID
+		}
+		return a.Unit < b.Unit
+	})
+
+	return report, nil
+}
+
+// trackedTime sums up the time tracked on each of the tasks; tasks nobody
+// tracked time on are left out.
+func (s *ReportService) trackedTime(tasks []repository.Task) (map[string]time.Duration, error) {
+	tracked := make(map[string]time.Duration)
+	if len(tasks) == 0 {
+		return tracked, nil
+	}
+
+	taskIDs := make([]string, len(tasks))
+	for i, task := range tasks {
+		taskIDs[i] = task.ID
+	}
+
+	repoEntries, err := s.timeRepo.List(repository.TimeEntryFilter{TaskIDs: taskIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, repoEntry := range repoEntries {
+		tracked[repoEntry.TaskID] += models.TimeEntryDuration(repoEntry, now)
+	}
+
+	return tracked, nil
+}
+
+func (s *ReportService) estimateUnit(projectID string, units map[string]models.EstimateUnit) (models.EstimateUnit, error) {
+	if projectID == "" {
+		return models.EstimateHours, nil
+	}
+
+	if unit, ok := units[projectID]; ok {
+		return unit, nil
+	}
+
+	unit := models.EstimateHours
+	project, err := s.projectRepo.GetByID(projectID)
+	if err != nil {
+		return "", err
+	}
+
+	if project != nil && models.EstimateUnit(project.EstimateUnit).IsValid() {
+		unit = models.EstimateUnit(project.EstimateUnit)
+	}
+
+	units[projectID] = unit
+	return unit, nil
+}
+
+// taskWorker is the user a task's work is credited to.
+func taskWorker(task repository.Task) string {
+	if task.AssigneeID != "" {
+		return task.AssigneeID
+	}
+	return task.UserID
+}
+
+// weekStart returns midnight UTC on the Monday of t's week.
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+func roundHundredths(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// estimateValue normalises a requested estimate: nil and 0 both mean none.
+func estimateValue(estimate *float64) *float64 {
+	if estimate == nil || *estimate == 0 {
+		return nil
+	}
+
+	value := *estimate
+	return &value
+}
+
+func formatEstimate(estimate *float64) string {
+	if estimate == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*estimate, 'f', -1, 64)
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"strings"
+	"todo-api/internal/models"
+	"todo-api/internal/repository"
+	"unicode"
+)
+
+const defaultSearchLimit = 20
+
+// SearchTasks finds the visible tasks whose title or description match the
+// query. Archived tasks are included, since search is how old tasks are found.
+func (s *TaskService) SearchTasks(query models.TaskSearchQuery, userID, workspaceID string) ([]models.TaskSearchResult, error) {
+	terms := parseSearchQuery(query.Q)
+	if len(terms) == 0 {
+		return nil, &QueryError{message: "Search query is required"}
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = defaultSearchLimit
+	}
+
+	visibleProjectIDs, err := s.access.visibleProjectIDs(userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := repository.TaskFilter{
+		WorkspaceID:       workspaceID,
+		VisibleTo:         userID,
+		VisibleProjectIDs: visibleProjectIDs,
+	}
+
+	switch query.ProjectID {
+	case "":
+	case models.InboxProjectID:
+		filter.ProjectID = new(string)
+	default:
+		filter.ProjectID = &query.ProjectID
+	}
+
+	hits, err := s.repo.Search(filter, repository.TaskSearch{Terms: terms, Limit: query.Limit})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]models.TaskSearchResult, len(hits))
+	for i, hit := range hits {
+		results[i] = models.TaskSearchResult{
+			Task:    models.ConvertFromRepositoryTask(hit.Task),
+			Rank:    hit.Rank,
+			Snippet: hit.Snippet,
+		}
+	}
+
+	return results, nil
+}
+
+// parseSearchQuery splits a query into terms. Text between double quotes is
+// a phrase, as is a word joined by punctuation like "follow-up"; a trailing *
+// turns the last word of a term into a prefix.
+func parseSearchQuery(q string) []repository.SearchTerm {
+	var terms []repository.SearchTerm
+	for i, segment := range strings.Split(q, `"`) {
+		chunks := strings.Fields(segment)
+		if i%2 == 1 {
+			chunks = []string{segment}
+		}
+
+		for _, chunk := range chunks {
+			chunk = strings.TrimSpace(chunk)
+			words := strings.FieldsFunc(strings.ToLower(chunk), func(r rune) bool {
+				return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+			})
+			if len(words) == 0 {
+				continue
+			}
+
+			terms = append(terms, repository.SearchTerm{
+				Words:  words,
+				Prefix: strings.HasSuffix(chunk, "*"),
+			})
+		}
+	}
+
+	return terms
+}
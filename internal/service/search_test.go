@@ -0,0 +1,51 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+	"todo-api/internal/repository"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []repository.SearchTerm
+	}{
+		{"empty", "", nil},
+		{"only punctuation", `-- "" *`, nil},
+		{"words", "Fix Login", []repository.SearchTerm{
+			{Words: []string{"fix"}},
+			{Words: []string{"login"}},
+		}},
+		{"quoted phrase", `"release notes" draft`, []repository.SearchTerm{
+			{Words: []string{"release", "notes"}},
+			{Words: []string{"draft"}},
+		}},
+		{"punctuation joins a phrase", "follow-up", []repository.SearchTerm{
+			{Words: []string{"follow", "up"}},
+		}},
+		{"prefix", "deploy*", []repository.SearchTerm{
+			{Words: []string{"deploy"}, Prefix: true},
+		}},
+		{"prefix phrase", `"release not*"`, []repository.SearchTerm{
+			{Words: []string{"release", "not"}, Prefix: true},
+		}},
+		{"unterminated quote", `bug "open issue`, []repository.SearchTerm{
+			{Words: []string{"bug"}},
+			{Words: []string{"open", "issue"}},
+		}},
+		{"unicode letters", "Überprüfung 2026", []repository.SearchTerm{
+			{Words: []string{"überprüfung"}},
+			{Words: []string{"2026"}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseSearchQuery(tt.query); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSearchQuery(%q) = %+v, want %+v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"errors"
+	"todo-api/internal/models"
+)
+
+func (s *TaskService) CreateSubtask(parentID string, req models.CreateTaskRequest, userID, workspaceID string) (*models.Task, error) {
+	req.ParentID = parentID
+	return s.CreateTask(req, userID, workspaceID)
+}
+
+func (s *TaskService) GetTaskTree(id, userID, workspaceID string) (*models.TaskTree, error) {
+	task, err := s.GetTask(id, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildTree(*task)
+}
+
+func (s *TaskService) buildTree(task models.Task) (*models.TaskTree, error) {
+	repoChildren, err := s.repo.GetChildren(task.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := models.TaskTree{
+		Task:     task,
+		Subtasks: make([]models.TaskTree, 0, len(repoChildren)),
+	}
+
+	for _, repoChild := range repoChildren {
+		child, err := s.buildTree(models.ConvertFromRepositoryTask(repoChild))
+		if err != nil {
+			return nil, err
+		}
+
+		tree.Progress.Total += child.Progress.Total + 1
+		tree.Progress.Completed += child.Progress.Completed
+		if child.Done {
+			tree.Progress.Completed++
+		}
+
+		tree.Subtasks = append(tree.Subtasks, *child)
+	}
+
+	return &tree, nil
+}
+
+// setParent moves a task under parentID, or to the top level when parentID is
+// empty, refusing moves that would make the task its own ancestor.
+func (s *TaskService) setParent(task *models.Task, parentID, userID string) error {
+	if parentID == "" {
+		task.ParentID = ""
+		return nil
+	}
+
+	parent, err := s.repo.GetByID(parentID)
+	if err != nil {
+		return err
+	}
+
+	if parent == nil {
+		return errors.New("Parent task not found")
+	}
+
+	if err := s.access.checkTask(*parent, userID, task.WorkspaceID, models.RoleEditor); err != nil {
+		return err
+	}
+
+	for ancestor := parent; ancestor != nil; {
+		if ancestor.ID == task.ID {
+			return errors.New("Task cannot be moved under its own subtask")
+		}
+
+		if ancestor.ParentID == "" {
+			break
+		}
+
+		ancestor, err = s.repo.GetByID(ancestor.ParentID)
+		if err != nil {
+			return err
+		}
+	}
+
+	task.ParentID = parentID
+	task.ProjectID = parent.ProjectID
+	return nil
+}
+
+func (s *TaskService) checkSubtasksCompleted(id string) error {
+	children, err := s.repo.GetChildren(id)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range children {
+		if !child.Done {
+			return errors.New("Task has unfinished subtasks")
+		}
+	}
+
+	return nil
+}
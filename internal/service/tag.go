@@ -0,0 +1,135 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"todo-api/internal/models"
+	"todo-api/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type TagService struct {
+	repo repository.TagRepository
+}
+
+func NewTagService(repo repository.TagRepository) *TagService {
+	return &TagService{repo: repo}
+}
+
+func (s *TagService) CreateTag(req models.CreateTagRequest, userID, workspaceID string) (*models.Tag, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("Tag name is required")
+	}
+
+	if err := s.ensureNameAvailable(name, "", userID, workspaceID); err != nil {
+		return nil, err
+	}
+
+	tag := models.Tag{
+		ID:          uuid.New().String(),
+		Name:        name,
+		Color:       req.Color,
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+	}
+
+	err := s.repo.Create(tag.ConvertToRepositoryTag())
+	if err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+func (s *TagService) GetTags(userID, workspaceID string) ([]models.Tag, error) {
+	repoTags, err := s.repo.GetByUserID(userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]models.Tag, len(repoTags))
+	for i, repoTag := range repoTags {
+		tags[i] = models.ConvertFromRepositoryTag(repoTag)
+	}
+
+	return tags, nil
+}
+
+func (s *TagService) GetTag(id, userID, workspaceID string) (*models.Tag, error) {
+	repoTag, err := s.getOwnedTag(id, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	tag := models.ConvertFromRepositoryTag(*repoTag)
+	return &tag, nil
+}
+
+func (s *TagService) UpdateTag(id string, req models.UpdateTagRequest, userID, workspaceID string) (*models.Tag, error) {
+	repoTag, err := s.getOwnedTag(id, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	tag := models.ConvertFromRepositoryTag(*repoTag)
+
+	if name := strings.TrimSpace(req.Name); name != "" && name != tag.Name {
+		if err := s.ensureNameAvailable(name, tag.ID, userID, workspaceID); err != nil {
+			return nil, err
+		}
+		tag.Name = name
+	}
+
+	if req.Color != "" {
+		tag.Color = req.Color
+	}
+
+	err = s.repo.Update(tag.ConvertToRepositoryTag())
+	if err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+func (s *TagService) DeleteTag(id, userID, workspaceID string) error {
+	if _, err := s.getOwnedTag(id, userID, workspaceID); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(id)
+}
+
+func (s *TagService) getOwnedTag(id, userID, workspaceID string) (*repository.Tag, error) {
+	repoTag, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if repoTag == nil || repoTag.WorkspaceID != workspaceID {
+		return nil, errors.New("Tag not found")
+	}
+
+	if repoTag.UserID != userID {
+		return nil, errors.New("Access denied")
+	}
+
+	return repoTag, nil
+}
+
+func (s *TagService) ensureNameAvailable(name, exceptID, userID, workspaceID string) error {
+	tags, err := s.repo.GetByUserID(userID, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		if tag.ID != exceptID && strings.EqualFold(tag.Name, name) {
+			return errors.New("Tag with this name exists")
+		}
+	}
+
+	return nil
+}
@@ -2,71 +2,339 @@ package service
 
 import (
 	"errors"
+	"strings"
+	"time"
 	"todo-api/internal/models"
 	"todo-api/internal/repository"
+	"todo-api/internal/storage"
 
 	"github.com/google/uuid"
 )
 
+type TaskOptions struct {
+	// RequireSubtasksCompleted prevents finishing a task while any of its subtasks are open.
+	RequireSubtasksCompleted bool
+}
+
 type TaskService struct {
-	repo repository.TaskRepository
+	repo           repository.TaskRepository
+	workspaceRepo  repository.WorkspaceRepository
+	tagRepo        repository.TagRepository
+	projectRepo    repository.ProjectRepository
+	attachmentRepo repository.AttachmentRepository
+	transitionRepo repository.TransitionRepository
+	workflowRepo   repository.WorkflowRepository
+	historyRepo    repository.HistoryRepository
+	transactor     repository.Transactor
+	store          storage.BlobStore
+	access         accessControl
+	opts           TaskOptions
 }
 
-func NewTaskService(repo repository.TaskRepository) *TaskService {
-	return &TaskService{repo: repo}
+func NewTaskService(repo *repository.Repository, store storage.BlobStore, opts TaskOptions) *TaskService {
+	return &TaskService{
+		repo:           repo.Task,
+		workspaceRepo:  repo.Workspace,
+		tagRepo:        repo.Tag,
+		projectRepo:    repo.Project,
+		attachmentRepo: repo.Attachment,
+		transitionRepo: repo.Transition,
+		workflowRepo:   repo.Workflow,
+		historyRepo:    repo.History,
+		transactor:     repo.Transactor,
+		store:          store,
+		access:         newAccessControl(repo),
+		opts:           opts,
+	}
 }
 
-func (s *TaskService) CreateTask(title, description, userID string) (*models.Task, error) {
-	if title == "" {
+func (s *TaskService) CreateTask(req models.CreateTaskRequest, userID, workspaceID string) (*models.Task, error) {
+	if req.Title == "" {
 		return nil, errors.New("Task title is required")
 	}
 
+	priority := req.Priority
+	if priority == "" {
+		priority = models.PriorityNone
+	}
+
+	if !priority.IsValid() {
+		return nil, errors.New("Invalid task priority")
+	}
+
 	task := models.Task{
 		ID:          uuid.New().String(),
-		Title:       title,
-		Description: description,
-		Status:      models.StatusNew,
+		Title:       req.Title,
+		Description: req.Description,
 		UserID:      userID,
+		WorkspaceID: workspaceID,
+		Priority:    priority,
+		Estimate:    estimateValue(req.Estimate),
+		CreatedAt:   time.Now().UTC(),
+		Tags:        []string{},
+		BlockedBy:   []string{},
+		Blocks:      []string{},
+	}
+
+	if err := setTaskDue(&task, req.DueDate, req.DueTime, req.DueTimezone); err != nil {
+		return nil, err
+	}
+	task.Overdue = task.IsOverdue(time.Now())
+
+	if req.Recurrence != "" {
+		if err := setTaskRecurrence(&task, req.Recurrence); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.ProjectID != "" {
+		if err := s.checkProjectAccess(req.ProjectID, userID, workspaceID); err != nil {
+			return nil, err
+		}
+		task.ProjectID = req.ProjectID
+	}
+
+	if req.AssigneeID != "" {
+		if err := checkWorkspaceMember(s.workspaceRepo, workspaceID, req.AssigneeID); err != nil {
+			return nil, err
+		}
+		task.AssigneeID = req.AssigneeID
+	}
+
+	if req.ParentID != "" {
+		if err := s.setParent(&task, req.ParentID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	w, err := loadWorkflow(s.workflowRepo, task.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.enterColumn(&task, w.initial()); err != nil {
+		return nil, err
 	}
 
 	repoTask := task.ConvertToRepositoryTask()
-	err := s.repo.Create(repoTask)
+	err = s.repo.Create(repoTask)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := s.recordHistory(nil, &task, userID); err != nil {
+		return nil, err
+	}
+
 	return &task, nil
 }
 
-func (s *TaskService) GetTask(id string) (*models.Task, error) {
-	repoTask, err := s.repo.GetByID(id)
+func (s *TaskService) GetTask(id, userID, workspaceID string) (*models.Task, error) {
+	repoTask, err := s.access.getTask(id, userID, workspaceID, models.RoleViewer)
 	if err != nil {
 		return nil, err
 	}
 
-	if repoTask == nil {
-		return nil, errors.New("Task not found")
-	}
-
 	task := models.ConvertFromRepositoryTask(*repoTask)
 	return &task, nil
 }
 
-func (s *TaskService) GetAllTasks() ([]models.Task, error) {
-	repoTasks, err := s.repo.GetAll()
+// GetAllTasks lists one page of the tasks userID can see. The page is cut
+// with a cursor on the sort keys, created_at and id rather than an offset, so
+// tasks added or removed meanwhile do not shift later pages.
+func (s *TaskService) GetAllTasks(query models.TaskQuery, userID, workspaceID string) (*models.TaskPage, error) {
+	filter, err := s.taskFilter(query, userID, workspaceID)
 	if err != nil {
 		return nil, err
 	}
 
-	tasks := make([]models.Task, len(repoTasks))
+	if query.Cursor != "" {
+		after, err := decodeTaskCursor(query.Cursor, query.Sort)
+		if err != nil {
+			return nil, &QueryError{message: err.Error()}
+		}
+		filter.After = after
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultTaskPageSize
+	}
+	// One extra task tells whether another page follows.
+	filter.Limit = limit + 1
+
+	repoTasks, err := s.repo.List(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.TaskPage{}
+	if len(repoTasks) > limit {
+		repoTasks = repoTasks[:limit]
+		page.NextCursor = encodeTaskCursor(repoTasks[limit-1], query.Sort)
+	}
+
+	page.Tasks = make([]models.Task, len(repoTasks))
 	for i, repoTask := range repoTasks {
-		tasks[i] = models.ConvertFromRepositoryTask(repoTask)
+		page.Tasks[i] = models.ConvertFromRepositoryTask(repoTask)
 	}
 
-	return tasks, nil
+	return page, nil
+}
+
+// QueryError reports a task listing whose filters, sort or cursor cannot be
+// parsed.
+type QueryError struct {
+	message string
+}
+
+func (e *QueryError) Error() string {
+	return e.message
+}
+
+// taskFilter turns the filters of a task query into a listing of the tasks
+// userID can see.
+func (s *TaskService) taskFilter(query models.TaskQuery, userID, workspaceID string) (repository.TaskFilter, error) {
+	visibleProjectIDs, err := s.access.visibleProjectIDs(userID, workspaceID)
+	if err != nil {
+		return repository.TaskFilter{}, err
+	}
+
+	filter := repository.TaskFilter{
+		WorkspaceID:       workspaceID,
+		VisibleTo:         userID,
+		VisibleProjectIDs: visibleProjectIDs,
+		ExcludeArchived:   !query.IncludeArchived,
+		Status:            query.Status,
+		Text:              query.Text,
+	}
+
+	switch query.Owner {
+	case "":
+	case models.AssigneeMe:
+		filter.UserID = userID
+	default:
+		filter.UserID = query.Owner
+	}
+
+	switch query.Assignee {
+	case "":
+	case models.AssigneeMe:
+		filter.AssigneeID = &userID
+	case models.AssigneeNone:
+		filter.AssigneeID = new(string)
+	default:
+		filter.AssigneeID = &query.Assignee
+	}
+
+	if query.DueBefore != "" {
+		dueBefore, err := parseDueBound(query.DueBefore)
+		if err != nil {
+			return filter, &QueryError{message: "Invalid due_before value"}
+		}
+		filter.DueBefore = &dueBefore
+	}
+
+	if query.DueAfter != "" {
+		dueAfter, err := parseDueBound(query.DueAfter)
+		if err != nil {
+			return filter, &QueryError{message: "Invalid due_after value"}
+		}
+		filter.DueAfter = &dueAfter
+	}
+
+	if query.CreatedBefore != "" {
+		createdBefore, err := parseDueBound(query.CreatedBefore)
+		if err != nil {
+			return filter, &QueryError{message: "Invalid created_before value"}
+		}
+		filter.CreatedBefore = &createdBefore
+	}
+
+	if query.CreatedAfter != "" {
+		createdAfter, err := parseDueBound(query.CreatedAfter)
+		if err != nil {
+			return filter, &QueryError{message: "Invalid created_after value"}
+		}
+		filter.CreatedAfter = &createdAfter
+	}
+
+	if query.Sort != "" {
+		sortKeys, err := parseTaskSort(query.Sort)
+		if err != nil {
+			return filter, &QueryError{message: err.Error()}
+		}
+		filter.Sort = sortKeys
+	}
+
+	if len(query.Tags) > 0 {
+		switch query.TagMode {
+		case "", models.TagModeAll:
+			filter.MatchAllTags = true
+		case models.TagModeAny:
+		default:
+			return filter, &QueryError{message: "Invalid tag_mode, expected all or any"}
+		}
+		filter.Tags = query.Tags
+		filter.TagUserID = userID
+	}
+
+	switch query.ProjectID {
+	case "":
+	case models.InboxProjectID:
+		filter.ProjectID = new(string)
+	default:
+		filter.ProjectID = &query.ProjectID
+	}
+
+	if query.Done != nil {
+		filter.ExcludeDone = !*query.Done
+		filter.OnlyDone = *query.Done
+	}
+
+	if query.Overdue {
+		now := time.Now()
+		if filter.DueBefore == nil || now.Before(*filter.DueBefore) {
+			filter.DueBefore = &now
+		}
+		filter.ExcludeDone = true
+	}
+
+	if query.CompletedBefore != "" {
+		completedBefore, err := parseDueBound(query.CompletedBefore)
+		if err != nil {
+			return filter, &QueryError{message: "Invalid completed_before value"}
+		}
+		filter.CompletedBefore = &completedBefore
+	}
+
+	if query.CompletedAfter != "" {
+		completedAfter, err := parseDueBound(query.CompletedAfter)
+		if err != nil {
+			return filter, &QueryError{message: "Invalid completed_after value"}
+		}
+		filter.CompletedAfter = &completedAfter
+	}
+
+	return filter, nil
 }
 
-func (s *TaskService) UpdateTask(id string, req models.UpdateTaskRequest, userID string) (*models.Task, error) {
+// UpdateTask writes a change together with everything it entails, such as the
+// next occurrence of a finished recurring task, in one transaction.
+func (s *TaskService) UpdateTask(id string, req models.UpdateTaskRequest, userID, workspaceID string) (*models.Task, error) {
+	var task *models.Task
+	err := s.inTransaction(func(tx *TaskService) error {
+		var err error
+		task, err = tx.updateTask(id, req, userID, workspaceID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+func (s *TaskService) updateTask(id string, req models.UpdateTaskRequest, userID, workspaceID string) (*models.Task, error) {
 	repoTask, err := s.repo.GetByID(id)
 	if err != nil {
 		return nil, err
@@ -76,8 +344,14 @@ func (s *TaskService) UpdateTask(id string, req models.UpdateTaskRequest, userID
 		return nil, errors.New("Task not found")
 	}
 
-	if repoTask.UserID != userID {
-		return nil, errors.New("Access denied")
+	if err := s.access.checkTask(*repoTask, userID, workspaceID, models.RoleEditor); err != nil {
+		if repoTask.AssigneeID != userID {
+			return nil, err
+		}
+
+		if !req.OnlyChangesStatus() {
+			return nil, errors.New("Assignees can only change the task status")
+		}
 	}
 
 	task := models.ConvertFromRepositoryTask(*repoTask)
@@ -90,8 +364,100 @@ func (s *TaskService) UpdateTask(id string, req models.UpdateTaskRequest, userID
 		task.Description = req.Description
 	}
 
-	if req.Status != "" {
-		task.Status = req.Status
+	if req.ProjectID != nil && *req.ProjectID != task.ProjectID {
+		if *req.ProjectID != "" {
+			if err := s.checkProjectAccess(*req.ProjectID, userID, workspaceID); err != nil {
+				return nil, err
+			}
+		}
+		// A subtask moved on its own becomes a top-level task of the new project.
+		task.ProjectID = *req.ProjectID
+		task.ParentID = ""
+	}
+
+	if req.ParentID != nil && *req.ParentID != task.ParentID {
+		if err := s.setParent(&task, *req.ParentID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	w, err := loadWorkflow(s.workflowRepo, task.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.ProjectID != repoTask.ProjectID {
+		if err := s.enterColumn(&task, w.mapStatus(string(task.Status), task.Done)); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Status != "" && !strings.EqualFold(string(req.Status), string(task.Status)) {
+		next, err := checkTransition(w, task, req.Status)
+		if err != nil {
+			return nil, err
+		}
+		if next.ID != w.initial().ID {
+			if err := s.checkDependenciesCompleted(task.ID); err != nil {
+				return nil, err
+			}
+		}
+		if next.Done && s.opts.RequireSubtasksCompleted {
+			if err := s.checkSubtasksCompleted(task.ID); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.enterColumn(&task, *next); err != nil {
+			return nil, err
+		}
+		// Reopening an archived task brings it back to the lists.
+		if !task.Done {
+			task.Archived, task.ArchivedAt = false, nil
+		}
+	}
+
+	if req.Priority != "" {
+		if !req.Priority.IsValid() {
+			return nil, errors.New("Invalid task priority")
+		}
+		task.Priority = req.Priority
+	}
+
+	if req.Estimate != nil {
+		task.Estimate = estimateValue(req.Estimate)
+	}
+
+	if req.DueDate != nil || req.DueTime != nil || req.DueTimezone != nil {
+		dueDate, dueTime, dueTimezone := task.DueDate, task.DueTime, task.DueTimezone
+		if req.DueDate != nil {
+			dueDate = *req.DueDate
+		}
+		if req.DueTime != nil {
+			dueTime = *req.DueTime
+		}
+		if req.DueTimezone != nil {
+			dueTimezone = *req.DueTimezone
+		}
+
+		if err := setTaskDue(&task, dueDate, dueTime, dueTimezone); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Recurrence != nil {
+		if err := setTaskRecurrence(&task, *req.Recurrence); err != nil {
+			return nil, err
+		}
+	} else if task.Recurrence != "" && task.DueAt == nil {
+		return nil, errors.New("Recurring tasks require a due date")
+	}
+
+	task.Overdue = task.IsOverdue(time.Now())
+
+	// A finished occurrence hands its recurrence rule over to the next one.
+	recurrence := ""
+	if task.Done && !repoTask.Done {
+		recurrence, task.Recurrence = task.Recurrence, ""
 	}
 
 	updatedRepoTask := task.ConvertToRepositoryTask()
@@ -100,22 +466,312 @@ func (s *TaskService) UpdateTask(id string, req models.UpdateTaskRequest, userID
 		return nil, err
 	}
 
+	if string(task.Status) != repoTask.Status {
+		if err := s.recordTransition(task.ID, models.TaskStatus(repoTask.Status), task.Status, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	before := models.ConvertFromRepositoryTask(*repoTask)
+	if err := s.recordHistory(&before, &task, userID); err != nil {
+		return nil, err
+	}
+
+	if task.ProjectID != repoTask.ProjectID {
+		if err := s.moveSubtasksToProject(task.ID, task.ProjectID, w, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if recurrence != "" {
+		if _, err := s.spawnNextOccurrence(task, recurrence, userID); err != nil {
+			return nil, err
+		}
+	}
+
 	return &task, nil
 }
 
-func (s *TaskService) DeleteTask(id, userID string) error {
+// DeleteTask moves a task and its subtasks to the trash, from where they can
+// be restored until they are purged.
+func (s *TaskService) DeleteTask(id, userID, workspaceID string) error {
+	if _, err := s.access.getTask(id, userID, workspaceID, models.RoleManager); err != nil {
+		return err
+	}
+
+	deleted, err := s.collectTasks(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.SoftDelete(id, time.Now().UTC()); err != nil {
+		return err
+	}
+
+	return s.recordChanges(deleted, userID)
+}
+
+// deleteProjectTasks removes the tasks of a project for good and returns
+// their attachments, whose blobs the caller releases once the removal is
+// committed.
+func (s *TaskService) deleteProjectTasks(projectID, userID string) ([]repository.Attachment, error) {
+	repoTasks, err := s.repo.List(repository.TaskFilter{ProjectID: &projectID})
+	if err != nil {
+		return nil, err
+	}
+
+	trashed, err := s.repo.List(repository.TaskFilter{ProjectID: &projectID, Trashed: true})
+	if err != nil {
+		return nil, err
+	}
+
+	// Subtasks always share the project of their parent, so this covers them.
+	attachments, err := s.collectAttachments(append(trashed, repoTasks...))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.deleteAttachments(attachments); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.DeleteByProjectID(projectID); err != nil {
+		return nil, err
+	}
+
+	if err := s.recordChanges(repoTasks, userID); err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+// collectTasks lists a task and all of its subtasks.
+func (s *TaskService) collectTasks(id string) ([]repository.Task, error) {
 	repoTask, err := s.repo.GetByID(id)
+	if err != nil || repoTask == nil {
+		return nil, err
+	}
+
+	tasks := []repository.Task{*repoTask}
+
+	children, err := s.repo.GetChildren(id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, child := range children {
+		subtree, err := s.collectTasks(child.ID)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, subtree...)
+	}
+
+	return tasks, nil
+}
+
+// collectAttachments lists the attachments of tasks that are about to be
+// removed for good.
+func (s *TaskService) collectAttachments(tasks []repository.Task) ([]repository.Attachment, error) {
+	var attachments []repository.Attachment
+	for _, task := range tasks {
+		taskAttachments, err := s.attachmentRepo.GetByTaskID(task.ID)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, taskAttachments...)
+	}
+
+	return attachments, nil
+}
+
+func (s *TaskService) deleteAttachments(attachments []repository.Attachment) error {
+	for _, attachment := range attachments {
+		if err := s.attachmentRepo.Delete(attachment.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *TaskService) AssignTask(id, assigneeID, userID, workspaceID string) (*models.Task, error) {
+	repoTask, err := s.access.getTask(id, userID, workspaceID, models.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	if assigneeID != "" {
+		if err := checkWorkspaceMember(s.workspaceRepo, workspaceID, assigneeID); err != nil {
+			return nil, err
+		}
+	}
+
+	before := models.ConvertFromRepositoryTask(*repoTask)
+
+	repoTask.AssigneeID = assigneeID
+	if err := s.repo.Update(*repoTask); err != nil {
+		return nil, err
+	}
+
+	task := models.ConvertFromRepositoryTask(*repoTask)
+	if err := s.recordHistory(&before, &task, userID); err != nil {
+		return nil, err
+	}
+
+	return &task, nil
+}
+
+func (s *TaskService) AttachTag(taskID, tagID, userID, workspaceID string) error {
+	if err := s.checkTagAccess(taskID, tagID, userID, workspaceID); err != nil {
+		return err
+	}
+
+	return s.trackChanges(taskID, userID, func() error {
+		return s.repo.AddTag(taskID, tagID)
+	})
+}
+
+func (s *TaskService) DetachTag(taskID, tagID, userID, workspaceID string) error {
+	if err := s.checkTagAccess(taskID, tagID, userID, workspaceID); err != nil {
+		return err
+	}
+
+	return s.trackChanges(taskID, userID, func() error {
+		return s.repo.RemoveTag(taskID, tagID)
+	})
+}
+
+func (s *TaskService) checkTagAccess(taskID, tagID, userID, workspaceID string) error {
+	if _, err := s.access.getTask(taskID, userID, workspaceID, models.RoleEditor); err != nil {
+		return err
+	}
+
+	repoTag, err := s.tagRepo.GetByID(tagID)
 	if err != nil {
 		return err
 	}
 
-	if repoTask == nil {
-		return errors.New("Task not found")
+	if repoTag == nil || repoTag.UserID != userID || repoTag.WorkspaceID != workspaceID {
+		return errors.New("Tag not found")
+	}
+
+	return nil
+}
+
+func (s *TaskService) checkProjectAccess(projectID, userID, workspaceID string) error {
+	_, err := s.access.getProject(projectID, userID, workspaceID, models.RoleEditor)
+	return err
+}
+
+// moveSubtasksToProject moves the subtasks of a task along with it, mapping
+// their statuses onto the workflow of the new project.
+func (s *TaskService) moveSubtasksToProject(parentID, projectID string, w *workflow, userID string) error {
+	children, err := s.repo.GetChildren(parentID)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range children {
+		before := child
+
+		child.ProjectID = projectID
+		status := w.mapStatus(child.Status, child.Done)
+		child.CompletedAt = completedAt(child.CompletedAt, child.Done, status)
+		child.StatusID = status.ID
+		if err := s.repo.Update(child); err != nil {
+			return err
+		}
+
+		if err := s.recordChanges([]repository.Task{before}, userID); err != nil {
+			return err
+		}
+
+		if err := s.moveSubtasksToProject(child.ID, projectID, w, userID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setTaskDue fills the due fields of a task. A task without an explicit due
+// time is due at the end of its due date in the given timezone.
+func setTaskDue(task *models.Task, dueDate, dueTime, dueTimezone string) error {
+	if dueDate == "" {
+		if dueTime != "" {
+			return errors.New("Due time requires a due date")
+		}
+		task.DueDate, task.DueTime, task.DueTimezone, task.DueAt = "", "", "", nil
+		return nil
+	}
+
+	loc := time.UTC
+	if dueTimezone != "" {
+		l, err := time.LoadLocation(dueTimezone)
+		if err != nil {
+			return errors.New("Invalid due timezone")
+		}
+		loc = l
 	}
 
-	if repoTask.UserID != userID {
-		return errors.New("Access denied")
+	date, err := time.ParseInLocation(models.DueDateLayout, dueDate, loc)
+	if err != nil {
+		return errors.New("Invalid due date, expected YYYY-MM-DD")
+	}
+
+	dueAt := time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 0, loc)
+	if dueTime != "" {
+		clock, err := time.Parse(models.DueTimeLayout, dueTime)
+		if err != nil {
+			return errors.New("Invalid due time, expected HH:MM")
+		}
+		dueAt = time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), 0, 0, loc)
+	}
+
+	task.DueDate = dueDate
+	task.DueTime = dueTime
+	task.DueTimezone = dueTimezone
+	task.DueAt = &dueAt
+
+	return nil
+}
+
+func parseDueBound(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(models.DueDateLayout, value)
+}
+
+var taskSortFields = map[string]string{
+	"created":   repository.SortByCreated,
+	"due":       repository.SortByDue,
+	"priority":  repository.SortByPriority,
+	"title":     repository.SortByTitle,
+	"status":    repository.SortByStatus,
+	"position":  repository.SortByPosition,
+	"completed": repository.SortByCompleted,
+}
+
+// parseTaskSort turns "priority,-due" into sort keys; a leading "-" sorts descending.
+func parseTaskSort(value string) ([]repository.SortKey, error) {
+	var keys []repository.SortKey
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		desc := strings.HasPrefix(part, "-")
+		field, ok := taskSortFields[strings.TrimPrefix(part, "-")]
+		if !ok {
+			return nil, errors.New("Unknown sort field: " + strings.TrimPrefix(part, "-"))
+		}
+
+		keys = append(keys, repository.SortKey{Field: field, Desc: desc})
 	}
 
-	return s.repo.Delete(id)
+	return keys, nil
 }
@@ -0,0 +1,296 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"todo-api/internal/models"
+	"todo-api/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+const maxTemplateTasks = 100
+
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)\s*\}\}`)
+
+type TemplateService struct {
+	repo        repository.TemplateRepository
+	taskService *TaskService
+}
+
+func NewTemplateService(repo *repository.Repository, taskService *TaskService) *TemplateService {
+	return &TemplateService{
+		repo:        repo.Template,
+		taskService: taskService,
+	}
+}
+
+// GetTemplates lists the user's own templates followed by the ones shared in
+// the workspace.
+func (s *TemplateService) GetTemplates(userID, workspaceID string) ([]models.Template, error) {
+	repoTemplates, err := s.repo.GetVisible(userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]models.Template, 0, len(repoTemplates))
+	for _, repoTemplate := range repoTemplates {
+		templates = append(templates, models.ConvertFromRepositoryTemplate(repoTemplate))
+	}
+
+	return templates, nil
+}
+
+func (s *TemplateService) GetTemplate(id, userID, workspaceID string) (*models.Template, error) {
+	repoTemplate, err := s.getTemplate(id, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	template := models.ConvertFromRepositoryTemplate(*repoTemplate)
+	return &template, nil
+}
+
+func (s *TemplateService) CreateTemplate(req models.CreateTemplateRequest, userID, workspaceID string) (*models.Template, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("Template name is required")
+	}
+
+	if err := validateTemplateTask(req.Task); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	template := models.Template{
+		ID:          uuid.New().String(),
+		Name:        name,
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		Shared:      req.Shared,
+		Task:        req.Task,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := s.repo.Create(template.ConvertToRepositoryTemplate()); err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+func (s *TemplateService) UpdateTemplate(id string, req models.UpdateTemplateRequest, userID, workspaceID string) (*models.Template, error) {
+	repoTemplate, err := s.getOwnTemplate(id, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	template := models.ConvertFromRepositoryTemplate(*repoTemplate)
+
+	if name := strings.TrimSpace(req.Name); name != "" {
+		template.Name = name
+	}
+
+	if req.Shared != nil {
+		template.Shared = *req.Shared
+	}
+
+	if req.Task != nil {
+		if err := validateTemplateTask(*req.Task); err != nil {
+			return nil, err
+		}
+		template.Task = *req.Task
+	}
+
+	template.UpdatedAt = time.Now().UTC()
+
+	if err := s.repo.Update(template.ConvertToRepositoryTemplate()); err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+func (s *TemplateService) DeleteTemplate(id, userID, workspaceID string) error {
+	if _, err := s.getOwnTemplate(id, userID, workspaceID); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(id)
+}
+
+// InstantiateTemplate creates the tasks of a template through the task
+// service, so they are checked and recorded like any other new task. Either
+// all of them are created or none is.
+func (s *TemplateService) InstantiateTemplate(id string, req models.InstantiateTemplateRequest, userID, workspaceID string) (*models.TaskTree, error) {
+	repoTemplate, err := s.getTemplate(id, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now().UTC().Truncate(24 * time.Hour)
+	if req.StartDate != "" {
+		if start, err = time.Parse(models.DueDateLayout, req.StartDate); err != nil {
+			return nil, errors.New("Invalid start date, expected YYYY-MM-DD")
+		}
+	}
+
+	template := models.ConvertFromRepositoryTemplate(*repoTemplate)
+	if missing := missingPlaceholders(template.Task, req.Values); len(missing) > 0 {
+		return nil, fmt.Errorf("Missing values for placeholders: %s", strings.Join(missing, ", "))
+	}
+
+	var rootID string
+	err = s.taskService.inTransaction(func(tx *TaskService) error {
+		root, err := createTemplateTask(tx, template.Task, "", req, start, userID, workspaceID)
+		if err != nil {
+			return err
+		}
+		rootID = root.ID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.taskService.GetTaskTree(rootID, userID, workspaceID)
+}
+
+func createTemplateTask(tx *TaskService, item models.TemplateTask, parentID string, req models.InstantiateTemplateRequest, start time.Time, userID, workspaceID string) (*models.Task, error) {
+	taskReq := models.CreateTaskRequest{
+		Title:       fillPlaceholders(item.Title, req.Values),
+		Description: fillPlaceholders(item.Description, req.Values),
+		Priority:    item.Priority,
+		Estimate:    item.Estimate,
+		AssigneeID:  req.AssigneeID,
+	}
+
+	if item.DueInDays != nil {
+		taskReq.DueDate = start.AddDate(0, 0, *item.DueInDays).Format(models.DueDateLayout)
+		taskReq.DueTime = item.DueTime
+	}
+
+	var task *models.Task
+	var err error
+	if parentID == "" {
+		taskReq.ProjectID = req.ProjectID
+		task, err = tx.CreateTask(taskReq, userID, workspaceID)
+	} else {
+		task, err = tx.CreateSubtask(parentID, taskReq, userID, workspaceID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, child := range item.Items {
+		if _, err := createTemplateTask(tx, child, task.ID, req, start, userID, workspaceID); err != nil {
+			return nil, err
+		}
+	}
+
+	return task, nil
+}
+
+// getTemplate loads a template that userID owns or that is shared in the
+// workspace.
+func (s *TemplateService) getTemplate(id, userID, workspaceID string) (*repository.Template, error) {
+	repoTemplate, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if repoTemplate == nil || repoTemplate.WorkspaceID != workspaceID {
+		return nil, errors.New("Template not found")
+	}
+
+	if repoTemplate.UserID != userID && !repoTemplate.Shared {
+		return nil, errors.New("Template not found")
+	}
+
+	return repoTemplate, nil
+}
+
+func (s *TemplateService) getOwnTemplate(id, userID, workspaceID string) (*repository.Template, error) {
+	repoTemplate, err := s.getTemplate(id, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if repoTemplate.UserID != userID {
+		return nil, errors.New("Only the owner can change a template")
+	}
+
+	return repoTemplate, nil
+}
+
+func validateTemplateTask(task models.TemplateTask) error {
+	count := 0
+	var validate func(item models.TemplateTask) error
+	validate = func(item models.TemplateTask) error {
+		count++
+		if count > maxTemplateTasks {
+			return fmt.Errorf("Templates can have at most %d tasks", maxTemplateTasks)
+		}
+
+		if strings.TrimSpace(item.Title) == "" {
+			return errors.New("Template task title is required")
+		}
+
+		if item.Priority != "" && !item.Priority.IsValid() {
+			return errors.New("Invalid task priority")
+		}
+
+		if item.DueTime != "" {
+			if item.DueInDays == nil {
+				return errors.New("Due time requires due_in_days")
+			}
+			if _, err := time.Parse(models.DueTimeLayout, item.DueTime); err != nil {
+				return errors.New("Invalid due time, expected HH:MM")
+			}
+		}
+
+		for _, child := range item.Items {
+			if err := validate(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return validate(task)
+}
+
+// missingPlaceholders lists, in order of first use, the placeholders of a
+// template that values has no entry for.
+func missingPlaceholders(task models.TemplateTask, values map[string]string) []string {
+	seen := make(map[string]bool)
+	var missing []string
+	var collect func(item models.TemplateTask)
+	collect = func(item models.TemplateTask) {
+		for _, text := range []string{item.Title, item.Description} {
+			for _, match := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+				name := match[1]
+				if _, ok := values[name]; !ok && !seen[name] {
+					seen[name] = true
+					missing = append(missing, name)
+				}
+			}
+		}
+		for _, child := range item.Items {
+			collect(child)
+		}
+	}
+
+	collect(task)
+	return missing
+}
+
+func fillPlaceholders(text string, values map[string]string) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		return values[placeholderPattern.FindStringSubmatch(placeholder)[1]]
+	})
+}
@@ -0,0 +1,396 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"todo-api/internal/models"
+	"todo-api/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type TimeService struct {
+	repo     repository.TimeEntryRepository
+	taskRepo repository.TaskRepository
+	access   accessControl
+	// timerMu serialises starting timers so a user never ends up with two
+	// running at once.
+	timerMu sync.Mutex
+}
+
+func NewTimeService(repo *repository.Repository) *TimeService {
+	return &TimeService{
+		repo:     repo.TimeEntry,
+		taskRepo: repo.Task,
+		access:   newAccessControl(repo),
+	}
+}
+
+func (s *TimeService) StartTimer(taskID, note, userID, workspaceID string) (*models.TimeEntry, error) {
+	if _, err := s.trackableTask(taskID, userID, workspaceID); err != nil {
+		return nil, err
+	}
+
+	s.timerMu.Lock()
+	defer s.timerMu.Unlock()
+
+	running, err := s.repo.GetRunning(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if running != nil {
+		return nil, errors.New("A timer is already running, stop it first")
+	}
+
+	now := time.Now().UTC()
+	entry := models.TimeEntry{
+		ID:        uuid.New().String(),
+		TaskID:    taskID,
+		UserID:    userID,
+		StartedAt: now,
+		Running:   true,
+		Note:      strings.TrimSpace(note),
+		CreatedAt: now,
+	}
+
+	if err := s.repo.Create(entry.ConvertToRepositoryTimeEntry()); err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+func (s *TimeService) StopTimer(userID string) (*models.TimeEntry, error) {
+	s.timerMu.Lock()
+	defer s.timerMu.Unlock()
+
+	running, err := s.repo.GetRunning(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if running == nil {
+		return nil, errors.New("No timer is running")
+	}
+
+	now := time.Now().UTC()
+	running.StoppedAt = &now
+	if err := s.repo.Update(*running); err != nil {
+		return nil, err
+	}
+
+	entry := models.ConvertFromRepositoryTimeEntry(*running)
+	return &entry, nil
+}
+
+func (s *TimeService) GetRunningTimer(userID string) (*models.TimeEntry, error) {
+	running, err := s.repo.GetRunning(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if running == nil {
+		return nil, errors.New("No timer is running")
+	}
+
+	entry := models.ConvertFromRepositoryTimeEntry(*running)
+	return &entry, nil
+}
+
+func (s *TimeService) GetTimeEntries(taskID, userID, workspaceID string) ([]models.TimeEntry, error) {
+	if _, err := s.access.getTask(taskID, userID, workspaceID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	repoEntries, err := s.repo.List(repository.TimeEntryFilter{TaskIDs: []string{taskID}})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]models.TimeEntry, len(repoEntries))
+	for i, repoEntry := range repoEntries {
+		entries[i] = models.ConvertFromRepositoryTimeEntry(repoEntry)
+	}
+
+	return entries, nil
+}
+
+func (s *TimeService) CreateTimeEntry(taskID string, req models.TimeEntryRequest, userID, workspaceID string) (*models.TimeEntry, error) {
+	if _, err := s.trackableTask(taskID, userID, workspaceID); err != nil {
+		return nil, err
+	}
+
+	startedAt := req.StartedAt.UTC()
+	stoppedAt := req.StoppedAt
+	switch {
+	case stoppedAt != nil && req.Duration > 0:
+		return nil, errors.New("Provide either stopped_at or duration, not both")
+	case stoppedAt != nil:
+		stopped := stoppedAt.UTC()
+		stoppedAt = &stopped
+	case req.Duration > 0:
+		stopped := startedAt.Add(time.Duration(req.Duration) * time.Second)
+		stoppedAt = &stopped
+	default:
+		return nil, errors.New("Either stopped_at or duration is required")
+	}
+
+	if err := validateTimeSpan(startedAt, *stoppedAt); err != nil {
+		return nil, err
+	}
+
+	entry := models.TimeEntry{
+		ID:        uuid.New().String(),
+		TaskID:    taskID,
+		UserID:    userID,
+		StartedAt: startedAt,
+		StoppedAt: stoppedAt,
+		Duration:  int64(stoppedAt.Sub(startedAt) / time.Second),
+		Note:      strings.TrimSpace(req.Note),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err := s.repo.Create(entry.ConvertToRepositoryTimeEntry()); err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// UpdateTimeEntry lets either the user who logged the time or a project
+// manager correct an entry. Setting stopped_at on a running entry stops it.
+func (s *TimeService) UpdateTimeEntry(taskID, id string, req models.UpdateTimeEntryRequest, userID, workspaceID string) (*models.TimeEntry, error) {
+	repoEntry, err := s.getEntry(taskID, id, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.StartedAt != nil {
+		repoEntry.StartedAt = req.StartedAt.UTC()
+	}
+
+	if req.StoppedAt != nil {
+		stoppedAt := req.StoppedAt.UTC()
+		repoEntry.StoppedAt = &stoppedAt
+	}
+
+	if req.Note != nil {
+		repoEntry.Note = strings.TrimSpace(*req.Note)
+	}
+
+	stoppedAt := time.Now().UTC()
+	if repoEntry.StoppedAt != nil {
+		stoppedAt = *repoEntry.StoppedAt
+	}
+
+	if err := validateTimeSpan(repoEntry.StartedAt, stoppedAt); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(*repoEntry); err != nil {
+		return nil, err
+	}
+
+	entry := models.ConvertFromRepositoryTimeEntry(*repoEntry)
+	return &entry, nil
+}
+
+func (s *TimeService) DeleteTimeEntry(taskID, id, userID, workspaceID string) error {
+	if _, err := s.getEntry(taskID, id, userID, workspaceID); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(id)
+}
+
+// GetTotals sums up the time logged on the tasks userID can see, grouped by
+// task, user or project.
+func (s *TimeService) GetTotals(query models.TimeTotalsQuery, userID, workspaceID string) (*models.TimeTotals, error) {
+	if query.GroupBy == "" {
+		query.GroupBy = models.TimeGroupByTask
+	}
+
+	switch query.GroupBy {
+	case models.TimeGroupByTask, models.TimeGroupByUser, models.TimeGroupByProject:
+	default:
+		return nil, errors.New("Invalid group_by, expected task, user or project")
+	}
+
+	var filter repository.TimeEntryFilter
+
+	if query.From != "" {
+		from, err := parseDueBound(query.From)
+		if err != nil {
+			return nil, errors.New("Invalid from value")
+		}
+		filter.StartedAfter = &from
+	}
+
+	if query.To != "" {
+		to, err := parseDueBound(query.To)
+		if err != nil {
+			return nil, errors.New("Invalid to value")
+		}
+		filter.StartedBefore = &to
+	}
+
+	switch query.UserID {
+	case "":
+	case models.AssigneeMe:
+		filter.UserID = userID
+	default:
+		filter.UserID = query.UserID
+	}
+
+	taskProjects, err := s.visibleTaskProjects(query, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.TaskIDs = make([]string, 0, len(taskProjects))
+	for taskID := range taskProjects {
+		filter.TaskIDs = append(filter.TaskIDs, taskID)
+	}
+
+	repoEntries, err := s.repo.List(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	totals := &models.TimeTotals{GroupBy: query.GroupBy, Groups: []models.TimeTotal{}}
+	seconds := make(map[string]int64)
+	for _, repoEntry := range repoEntries {
+		duration := int64(models.TimeEntryDuration(repoEntry, now) / time.Second)
+
+		var key string
+		switch query.GroupBy {
+		case models.TimeGroupByTask:
+			key = repoEntry.TaskID
+		case models.TimeGroupByUser:
+			key = repoEntry.UserID
+		case models.TimeGroupByProject:
+			key = taskProjects[repoEntry.TaskID]
+			if key == "" {
+				key = models.InboxProjectID
+			}
+		}
+
+		seconds[key] += duration
+		totals.Total += duration
+	}
+
+	for id, total := range seconds {
+		totals.Groups = append(totals.Groups, models.TimeTotal{ID: id, Seconds: total})
+	}
+
+	sort.Slice(totals.Groups, func(i, j int) bool {
+		if totals.Groups[i].Seconds != totals.Groups[j].Seconds {
+			return totals.Groups[i].Seconds > totals.Groups[j].Seconds
+		}
+		return totals.Groups[i].ID < totals.Groups[j].ID
+	})
+
+	return totals, nil
+}
+
+// visibleTaskProjects maps the tasks a totals query covers to their project.
+func (s *TimeService) visibleTaskProjects(query models.TimeTotalsQuery, userID, workspaceID string) (map[string]string, error) {
+	if query.TaskID != "" {
+		repoTask, err := s.access.getTask(query.TaskID, userID, workspaceID, models.RoleViewer)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{repoTask.ID: repoTask.ProjectID}, nil
+	}
+
+	visibleProjectIDs, err := s.access.visibleProjectIDs(userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := repository.TaskFilter{
+		WorkspaceID:       workspaceID,
+		VisibleTo:         userID,
+		VisibleProjectIDs: visibleProjectIDs,
+	}
+
+	switch query.ProjectID {
+	case "":
+	case models.InboxProjectID:
+		filter.ProjectID = new(string)
+	default:
+		filter.ProjectID = &query.ProjectID
+	}
+
+	repoTasks, err := s.taskRepo.List(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	taskProjects := make(map[string]string, len(repoTasks))
+	for _, repoTask := range repoTasks {
+		taskProjects[repoTask.ID] = repoTask.ProjectID
+	}
+
+	return taskProjects, nil
+}
+
+// trackableTask loads a task userID may log time on: editors of the task and
+// its assignee.
+func (s *TimeService) trackableTask(taskID, userID, workspaceID string) (*repository.Task, error) {
+	repoTask, err := s.access.getTask(taskID, userID, workspaceID, models.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	if repoTask.AssigneeID == userID {
+		return repoTask, nil
+	}
+
+	if err := s.access.checkTask(*repoTask, userID, workspaceID, models.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	return repoTask, nil
+}
+
+// getEntry loads an entry that userID may change: their own, or any entry
+// on a task they manage.
+func (s *TimeService) getEntry(taskID, id, userID, workspaceID string) (*repository.TimeEntry, error) {
+	repoEntry, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if repoEntry == nil || repoEntry.TaskID != taskID {
+		return nil, errors.New("Time entry not found")
+	}
+
+	required := models.RoleManager
+	if repoEntry.UserID == userID {
+		required = models.RoleViewer
+	}
+
+	if _, err := s.access.getTask(taskID, userID, workspaceID, required); err != nil {
+		return nil, err
+	}
+
+	return repoEntry, nil
+}
+
+func validateTimeSpan(startedAt, stoppedAt time.Time) error {
+	if !stoppedAt.After(startedAt) {
+		return errors.New("Stop time must be after start time")
+	}
+
+	if stoppedAt.After(time.Now()) {
+		return errors.New("Time entries cannot end in the future")
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"errors"
+	"time"
+	"todo-api/internal/models"
+	"todo-api/internal/repository"
+)
+
+// GetTrash lists the deleted tasks userID can see. Subtasks that were deleted
+// together with their parent are left out; they come back with it.
+func (s *TaskService) GetTrash(userID, workspaceID string) ([]models.Task, error) {
+	visibleProjectIDs, err := s.access.visibleProjectIDs(userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	repoTasks, err := s.repo.List(repository.TaskFilter{
+		WorkspaceID:       workspaceID,
+		VisibleTo:         userID,
+		VisibleProjectIDs: visibleProjectIDs,
+		Trashed:           true,
+		Sort:              []repository.SortKey{{Field: repository.SortByDeleted, Desc: true}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deletedAt := make(map[string]time.Time, len(repoTasks))
+	for _, repoTask := range repoTasks {
+		deletedAt[repoTask.ID] = *repoTask.DeletedAt
+	}
+
+	tasks := []models.Task{}
+	for _, repoTask := range repoTasks {
+		if parentDeletedAt, ok := deletedAt[repoTask.ParentID]; ok && parentDeletedAt.Equal(*repoTask.DeletedAt) {
+			continue
+		}
+		tasks = append(tasks, models.ConvertFromRepositoryTask(repoTask))
+	}
+
+	return tasks, nil
+}
+
+func (s *TaskService) RestoreTask(id, userID, workspaceID string) (*models.Task, error) {
+	repoTask, err := s.getTrashed(id, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if repoTask.ParentID != "" {
+		parent, err := s.repo.GetByID(repoTask.ParentID)
+		if err != nil {
+			return nil, err
+		}
+
+		if parent == nil {
+			return nil, errors.New("Restore the parent task first")
+		}
+	}
+
+	if err := s.repo.Restore(id); err != nil {
+		return nil, err
+	}
+
+	restored, err := s.collectTasks(id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, restoredTask := range restored {
+		if err := s.recordEvent(restoredTask.ID, models.HistoryActionRestored, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetTask(id, userID, workspaceID)
+}
+
+// PurgeTask removes a deleted task and its subtasks for good.
+func (s *TaskService) PurgeTask(id, userID, workspaceID string) error {
+	repoTask, err := s.getTrashed(id, userID, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	return s.purge(*repoTask, userID)
+}
+
+// PurgeExpired purges every task that has been in the trash for longer than
+// retention and reports how many were purged.
+func (s *TaskService) PurgeExpired(retention time.Duration) (int, error) {
+	cutoff := time.Now().UTC().Add(-retention)
+	repoTasks, err := s.repo.List(repository.TaskFilter{Trashed: true, DeletedBefore: &cutoff})
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, repoTask := range repoTasks {
+		// Subtasks go away with their parent and may already be gone.
+		current, err := s.repo.GetTrashed(repoTask.ID)
+		if err != nil {
+			return purged, err
+		}
+
+		if current == nil {
+			continue
+		}
+
+		if err := s.purge(*current, ""); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+func (s *TaskService) getTrashed(id, userID, workspaceID string) (*repository.Task, error) {
+	repoTask, err := s.repo.GetTrashed(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if repoTask == nil {
+		return nil, errors.New("Task not found")
+	}
+
+	if err := s.access.checkTask(*repoTask, userID, workspaceID, models.RoleManager); err != nil {
+		return nil, err
+	}
+
+	return repoTask, nil
+}
+
+// purge deletes a trashed task for good and records who purged it; userID is
+// empty when the task expired from the trash.
+func (s *TaskService) purge(repoTask repository.Task, userID string) error {
+	tasks, err := s.collectTrash(repoTask)
+	if err != nil {
+		return err
+	}
+
+	attachments, err := s.collectAttachments(tasks)
+	if err != nil {
+		return err
+	}
+
+	if err := s.deleteAttachments(attachments); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(repoTask.ID); err != nil {
+		return err
+	}
+
+	if err := releaseBlobs(s.attachmentRepo, s.store, attachments); err != nil {
+		return err
+	}
+
+	return s.recordEvent(repoTask.ID, models.HistoryActionPurged, userID)
+}
+
+// collectTrash lists a deleted task and all of its subtasks, which are in the
+// trash as well.
+func (s *TaskService) collectTrash(repoTask repository.Task) ([]repository.Task, error) {
+	tasks := []repository.Task{repoTask}
+
+	children, err := s.repo.List(repository.TaskFilter{ParentID: &repoTask.ID, Trashed: true})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, child := range children {
+		subtree, err := s.collectTrash(child)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, subtree...)
+	}
+
+	return tasks, nil
+}
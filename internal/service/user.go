@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"time"
 	"todo-api/internal/models"
 	"todo-api/internal/repository"
 
@@ -10,14 +11,19 @@ import (
 )
 
 type UserService struct {
-	repo repository.UserRepository
+	repo          repository.UserRepository
+	workspaceRepo repository.WorkspaceRepository
 }
 
-func NewUserService(repo repository.UserRepository) *UserService {
-	return &UserService{repo: repo}
+func NewUserService(repo *repository.Repository) *UserService {
+	return &UserService{
+		repo:          repo.User,
+		workspaceRepo: repo.Workspace,
+	}
 }
 
-func (s *UserService) CreateUser(name, email, password string) (*models.UserResponse, error) {
+// CreateUser registers a user as a member of the given workspace.
+func (s *UserService) CreateUser(name, email, password, workspaceID string) (*models.UserResponse, error) {
 	existingUser, _ := s.repo.GetByEmail(email)
 	if existingUser != nil {
 		return nil, errors.New("User with this email exists")
@@ -29,10 +35,11 @@ func (s *UserService) CreateUser(name, email, password string) (*models.UserResp
 	}
 
 	user := models.User{
-		ID:       uuid.New().String(),
-		Name:     name,
-		Email:    email,
-		Password: string(hashedPassword),
+		ID:          uuid.New().String(),
+		Name:        name,
+		Email:       email,
+		Password:    string(hashedPassword),
+		WorkspaceID: workspaceID,
 	}
 
 	repoUser := user.ConvertToRepositoryUser()
@@ -41,6 +48,16 @@ func (s *UserService) CreateUser(name, email, password string) (*models.UserResp
 		return nil, err
 	}
 
+	err = s.workspaceRepo.SaveMember(repository.WorkspaceMember{
+		WorkspaceID: workspaceID,
+		UserID:      user.ID,
+		Role:        models.WorkspaceRoleMember,
+		CreatedAt:   time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	return &models.UserResponse{
 		ID:    user.ID,
 		Name:  user.Name,
@@ -48,7 +65,11 @@ func (s *UserService) CreateUser(name, email, password string) (*models.UserResp
 	}, nil
 }
 
-func (s *UserService) GetUser(id string) (*models.UserResponse, error) {
+func (s *UserService) GetUser(id, workspaceID string) (*models.UserResponse, error) {
+	if err := checkWorkspaceMember(s.workspaceRepo, workspaceID, id); err != nil {
+		return nil, err
+	}
+
 	repoUser, err := s.repo.GetByID(id)
 	if err != nil {
 		return nil, err
@@ -65,8 +86,8 @@ func (s *UserService) GetUser(id string) (*models.UserResponse, error) {
 	}, nil
 }
 
-func (s *UserService) GetAllUsers() ([]models.UserResponse, error) {
-	repoUsers, err := s.repo.GetAll()
+func (s *UserService) GetAllUsers(workspaceID string) ([]models.UserResponse, error) {
+	repoUsers, err := s.repo.GetByWorkspaceID(workspaceID)
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +104,12 @@ func (s *UserService) GetAllUsers() ([]models.UserResponse, error) {
 	return userResponses, nil
 }
 
-func (s *UserService) UpdateUser(id string, req models.UpdateUserRequest) (*models.UserResponse, error) {
+// UpdateUser changes the profile and credentials of userID's own account.
+func (s *UserService) UpdateUser(id string, req models.UpdateUserRequest, userID string) (*models.UserResponse, error) {
+	if id != userID {
+		return nil, errors.New("Access denied")
+	}
+
 	repoUser, err := s.repo.GetByID(id)
 	if err != nil {
 		return nil, err
@@ -130,6 +156,11 @@ func (s *UserService) UpdateUser(id string, req models.UpdateUserRequest) (*mode
 	}, nil
 }
 
-func (s *UserService) DeleteUser(id string) error {
+// DeleteUser deletes userID's own account.
+func (s *UserService) DeleteUser(id, userID string) error {
+	if id != userID {
+		return errors.New("Access denied")
+	}
+
 	return s.repo.Delete(id)
 }
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"todo-api/internal/models"
+	"todo-api/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// systemViews are the built-in views every user has. Their filters are
+// rebuilt on each use since they are relative to the current time; days
+// start at midnight UTC.
+var systemViews = []struct {
+	id     string
+	name   string
+	filter func(now time.Time) models.ViewFilter
+}{
+	{"today", "Today", func(now time.Time) models.ViewFilter {
+		today := now.UTC().Truncate(24 * time.Hour)
+		return models.ViewFilter{
+			DueAfter:  today.Format(time.RFC3339),
+			DueBefore: today.AddDate(0, 0, 1).Format(time.RFC3339),
+			Done:      new(bool),
+			Sort:      "due",
+		}
+	}},
+	{"overdue", "Overdue", func(now time.Time) models.ViewFilter {
+		return models.ViewFilter{Overdue: true, Sort: "due"}
+	}},
+	{"recently-finished", "Recently finished", func(now time.Time) models.ViewFilter {
+		done := true
+		return models.ViewFilter{
+			Done:            &done,
+			CompletedAfter:  now.UTC().AddDate(0, 0, -7).Format(time.RFC3339),
+			Sort:            "-completed",
+			IncludeArchived: true,
+		}
+	}},
+}
+
+type ViewService struct {
+	repo          repository.ViewRepository
+	workspaceRepo repository.WorkspaceRepository
+	taskService   *TaskService
+}
+
+func NewViewService(repo *repository.Repository, taskService *TaskService) *ViewService {
+	return &ViewService{
+		repo:          repo.View,
+		workspaceRepo: repo.Workspace,
+		taskService:   taskService,
+	}
+}
+
+// GetViews lists the system views followed by the user's own views and the
+// views shared with them.
+func (s *ViewService) GetViews(userID, workspaceID string) ([]models.View, error) {
+	now := time.Now()
+	views := make([]models.View, 0, len(systemViews))
+	for _, system := range systemViews {
+		views = append(views, systemView(system.id, system.name, system.filter(now)))
+	}
+
+	owned, err := s.repo.GetByUserID(userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	shared, err := s.repo.GetSharedWithUser(userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, repoView := range append(owned, shared...) {
+		view, err := s.withShares(repoView)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, *view)
+	}
+
+	return views, nil
+}
+
+func (s *ViewService) GetView(id, userID, workspaceID string) (*models.View, error) {
+	if view, ok := findSystemView(id, time.Now()); ok {
+		return &view, nil
+	}
+
+	repoView, err := s.getView(id, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withShares(*repoView)
+}
+
+func (s *ViewService) CreateView(req models.CreateViewRequest, userID, workspaceID string) (*models.View, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("View name is required")
+	}
+
+	if _, err := s.taskService.taskFilter(req.Filter.TaskQuery(), userID, workspaceID); err != nil {
+		return nil, err
+	}
+
+	sharedWith, err := s.shareTargets(req.SharedWith, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	view := models.View{
+		ID:          uuid.New().String(),
+		Name:        name,
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		Filter:      req.Filter,
+		SharedWith:  sharedWith,
+		CreatedAt:   &now,
+		UpdatedAt:   &now,
+	}
+
+	if err := s.repo.Create(view.ConvertToRepositoryView()); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SetShares(view.ID, sharedWith); err != nil {
+		return nil, err
+	}
+
+	return &view, nil
+}
+
+func (s *ViewService) UpdateView(id string, req models.UpdateViewRequest, userID, workspaceID string) (*models.View, error) {
+	repoView, err := s.getOwnView(id, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	view, err := s.withShares(*repoView)
+	if err != nil {
+		return nil, err
+	}
+
+	if name := strings.TrimSpace(req.Name); name != "" {
+		view.Name = name
+	}
+
+	if req.Filter != nil {
+		if _, err := s.taskService.taskFilter(req.Filter.TaskQuery(), userID, workspaceID); err != nil {
+			return nil, err
+		}
+		view.Filter = *req.Filter
+	}
+
+	if req.SharedWith != nil {
+		sharedWith, err := s.shareTargets(req.SharedWith, userID, workspaceID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.repo.SetShares(id, sharedWith); err != nil {
+			return nil, err
+		}
+		view.SharedWith = sharedWith
+	}
+
+	now := time.Now().UTC()
+	view.UpdatedAt = &now
+
+	if err := s.repo.Update(view.ConvertToRepositoryView()); err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
+
+func (s *ViewService) DeleteView(id, userID, workspaceID string) error {
+	if _, err := s.getOwnView(id, userID, workspaceID); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(id)
+}
+
+// GetViewTasks lists a page of the tasks matching a view. A shared view
+// shows each user the tasks they can see, with "me" standing for them.
+func (s *ViewService) GetViewTasks(id string, query models.ViewTasksQuery, userID, workspaceID string) (*models.TaskPage, error) {
+	view, err := s.GetView(id, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	taskQuery := view.Filter.TaskQuery()
+	taskQuery.Limit = query.Limit
+	taskQuery.Cursor = query.Cursor
+
+	return s.taskService.GetAllTasks(taskQuery, userID, workspaceID)
+}
+
+// getView loads a view that userID owns or that was shared with them.
+func (s *ViewService) getView(id, userID, workspaceID string) (*repository.View, error) {
+	repoView, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if repoView == nil || repoView.WorkspaceID != workspaceID {
+		return nil, errors.New("View not found")
+	}
+
+	if repoView.UserID == userID {
+		return repoView, nil
+	}
+
+	sharedWith, err := s.repo.GetShares(id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sharedUserID := range sharedWith {
+		if sharedUserID == userID {
+			return repoView, nil
+		}
+	}
+
+	return nil, errors.New("View not found")
+}
+
+func (s *ViewService) getOwnView(id, userID, workspaceID string) (*repository.View, error) {
+	if _, ok := findSystemView(id, time.Now()); ok {
+		return nil, errors.New("System views cannot be changed")
+	}
+
+	repoView, err := s.getView(id, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if repoView.UserID != userID {
+		return nil, errors.New("Only the owner can change a view")
+	}
+
+	return repoView, nil
+}
+
+// shareTargets validates the users a view is to be shared with, dropping
+// duplicates and the owner.
+func (s *ViewService) shareTargets(userIDs []string, ownerID, workspaceID string) ([]string, error) {
+	seen := make(map[string]bool, len(userIDs))
+	targets := []string{}
+	for _, userID := range userIDs {
+		if userID == ownerID || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		if err := checkWorkspaceMember(s.workspaceRepo, workspaceID, userID); err != nil {
+			return nil, err
+		}
+		targets = append(targets, userID)
+	}
+
+	return targets, nil
+}
+
+func (s *ViewService) withShares(repoView repository.View) (*models.View, error) {
+	view := models.ConvertFromRepositoryView(repoView)
+
+	sharedWith, err := s.repo.GetShares(repoView.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sharedWith != nil {
+		view.SharedWith = sharedWith
+	}
+
+	return &view, nil
+}
+
+func systemView(id, name string, filter models.ViewFilter) models.View {
+	return models.View{
+		ID:         id,
+		Name:       name,
+		System:     true,
+		Filter:     filter,
+		SharedWith: []string{},
+	}
+}
+
+func findSystemView(id string, now time.Time) (models.View, bool) {
+	for _, system := range systemViews {
+		if system.id == id {
+			return systemView(system.id, system.name, system.filter(now)), true
+		}
+	}
+	return models.View{}, false
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"todo-api/internal/models"
+	"todo-api/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// WorkflowError reports a status change the task workflow does not allow, or
+// a workflow that cannot be used.
+type WorkflowError struct {
+	message string
+}
+
+func (e *WorkflowError) Error() string {
+	return e.message
+}
+
+// TransitionTask moves a task to another status of its workflow.
+func (s *TaskService) TransitionTask(id string, status models.TaskStatus, userID, workspaceID string) (*models.Task, error) {
+	repoTask, err := s.access.getTask(id, userID, workspaceID, models.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.EqualFold(repoTask.Status, string(status)) {
+		return nil, &WorkflowError{message: fmt.Sprintf("Task is already %s", status)}
+	}
+
+	return s.UpdateTask(id, models.UpdateTaskRequest{Status: status}, userID, workspaceID)
+}
+
+func (s *TaskService) GetTransitions(id, userID, workspaceID string) ([]models.TaskTransition, error) {
+	if _, err := s.access.getTask(id, userID, workspaceID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	repoTransitions, err := s.transitionRepo.GetByTaskID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	transitions := make([]models.TaskTransition, len(repoTransitions))
+	for i, repoTransition := range repoTransitions {
+		transitions[i] = models.ConvertFromRepositoryTransition(repoTransition)
+	}
+
+	return transitions, nil
+}
+
+func (s *TaskService) recordTransition(taskID string, from, to models.TaskStatus, userID string) error {
+	transition := models.TaskTransition{
+		ID:         uuid.New().String(),
+		TaskID:     taskID,
+		FromStatus: from,
+		ToStatus:   to,
+		UserID:     userID,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	return s.transitionRepo.Create(transition.ConvertToRepositoryTransition())
+}
+
+// workflow is the set of statuses tasks of one project move through, in order.
+type workflow struct {
+	projectID   string
+	statuses    []repository.WorkflowStatus
+	transitions map[string]map[string]bool
+}
+
+// loadWorkflow returns the workflow of a project, falling back to the default
+// workflow when the project has not defined its own.
+func loadWorkflow(repo repository.WorkflowRepository, projectID string) (*workflow, error) {
+	statuses, err := repo.GetStatuses(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(statuses) == 0 && projectID != "" {
+		return loadWorkflow(repo, "")
+	}
+
+	if len(statuses) == 0 {
+		return nil, errors.New("Default workflow is missing")
+	}
+
+	transitions, err := repo.GetTransitions(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newWorkflow(projectID, statuses, transitions), nil
+}
+
+func newWorkflow(projectID string, statuses []repository.WorkflowStatus, transitions []repository.WorkflowTransition) *workflow {
+	w := &workflow{
+		projectID:   projectID,
+		statuses:    statuses,
+		transitions: make(map[string]map[string]bool),
+	}
+
+	for _, transition := range transitions {
+		if w.transitions[transition.FromStatusID] == nil {
+			w.transitions[transition.FromStatusID] = make(map[string]bool)
+		}
+		w.transitions[transition.FromStatusID][transition.ToStatusID] = true
+	}
+
+	return w
+}
+
+// initial is the status new tasks start in.
+func (w *workflow) initial() repository.WorkflowStatus {
+	return w.statuses[0]
+}
+
+func (w *workflow) status(name string) *repository.WorkflowStatus {
+	for i, status := range w.statuses {
+		if strings.EqualFold(status.Name, name) {
+			return &w.statuses[i]
+		}
+	}
+	return nil
+}
+
+func (w *workflow) allows(fromID, toID string) bool {
+	if len(w.transitions) == 0 {
+		return true
+	}
+	return w.transitions[fromID][toID]
+}
+
+// mapStatus finds the status a task coming from another workflow lands in:
+// the status with the same name, otherwise the first done status for
+// finished tasks and the initial status for open ones.
+func (w *workflow) mapStatus(name string, done bool) repository.WorkflowStatus {
+	if status := w.status(name); status != nil {
+		return *status
+	}
+
+	if done {
+		for _, status := range w.statuses {
+			if status.Done {
+				return status
+			}
+		}
+	}
+
+	return w.initial()
+}
+
+func (w *workflow) model() models.Workflow {
+	workflow := models.Workflow{
+		Custom:      w.projectID != "",
+		ProjectID:   w.projectID,
+		Statuses:    make([]models.WorkflowStatus, len(w.statuses)),
+		Transitions: []models.WorkflowTransition{},
+	}
+
+	for i, status := range w.statuses {
+		workflow.Statuses[i] = models.ConvertFromRepositoryWorkflowStatus(status)
+		for _, target := range w.statuses {
+			if w.transitions[status.ID][target.ID] {
+				workflow.Transitions = append(workflow.Transitions, models.WorkflowTransition{
+					From: models.TaskStatus(status.Name),
+					To:   models.TaskStatus(target.Name),
+				})
+			}
+		}
+	}
+
+	return workflow
+}
+
+func setTaskStatus(task *models.Task, status repository.WorkflowStatus) {
+	task.CompletedAt = completedAt(task.CompletedAt, task.Done, status)
+	task.StatusID = status.ID
+	task.Status = models.TaskStatus(status.Name)
+	task.Done = status.Done
+}
+
+// completedAt is the completion time of a task entering status: kept while the
+// task stays done, set when it becomes done and cleared when it is reopened.
+func completedAt(current *time.Time, done bool, status repository.WorkflowStatus) *time.Time {
+	if !status.Done {
+		return nil
+	}
+	if !done || current == nil {
+		now := time.Now().UTC()
+		return &now
+	}
+
+	return current
+}
+
+// checkTransition resolves the status the task may move to in its workflow.
+func checkTransition(w *workflow, task models.Task, to models.TaskStatus) (*repository.WorkflowStatus, error) {
+	next := w.status(string(to))
+	if next == nil {
+		return nil, &WorkflowError{message: fmt.Sprintf("Invalid task status: %s", to)}
+	}
+
+	if !w.allows(task.StatusID, next.ID) {
+		return nil, &WorkflowError{message: fmt.Sprintf("Cannot move task from %s to %s", task.Status, next.Name)}
+	}
+
+	return next, nil
+}
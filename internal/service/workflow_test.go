@@ -0,0 +1,73 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"todo-api/internal/models"
+	"todo-api/internal/repository"
+)
+
+func testStatuses() []repository.WorkflowStatus {
+	return []repository.WorkflowStatus{
+		{ID: "todo", Name: "To do", Position: 0},
+		{ID: "doing", Name: "Doing", Position: 1},
+		{ID: "done", Name: "Done", Position: 2, Done: true},
+	}
+}
+
+func TestCheckTransition(t *testing.T) {
+	restricted := []repository.WorkflowTransition{
+		{FromStatusID: "todo", ToStatusID: "doing"},
+		{FromStatusID: "doing", ToStatusID: "done"},
+		{FromStatusID: "done", ToStatusID: "todo"},
+	}
+
+	tests := []struct {
+		name        string
+		transitions []repository.WorkflowTransition
+		from        string
+		to          models.TaskStatus
+		want        string
+		wantErr     string
+	}{
+		{"any move without transitions", nil, "todo", "Done", "done", ""},
+		{"allowed move", restricted, "todo", "Doing", "doing", ""},
+		{"status names ignore case", restricted, "doing", "done", "done", ""},
+		{"move not allowed", restricted, "todo", "Done", "", "Cannot move task from To do to Done"},
+		{"reverse of an allowed move", restricted, "doing", "To do", "", "Cannot move task from Doing to To do"},
+		{"unknown status", restricted, "todo", "Blocked", "", "Invalid task status: Blocked"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newWorkflow("", testStatuses(), tt.transitions)
+			from := statusByID(w.statuses, tt.from)
+			task := models.Task{StatusID: from.ID, Status: models.TaskStatus(from.Name)}
+
+			next, err := checkTransition(w, task, tt.to)
+			if tt.wantErr != "" {
+				var workflowErr *WorkflowError
+				if !errors.As(err, &workflowErr) || err.Error() != tt.wantErr {
+					t.Fatalf("checkTransition() error = %v, want WorkflowError %q", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("checkTransition() returned error: %v", err)
+			}
+			if next.ID != tt.want {
+				t.Errorf("checkTransition() = %s, want %s", next.ID, tt.want)
+			}
+		})
+	}
+}
+
+func statusByID(statuses []repository.WorkflowStatus, id string) repository.WorkflowStatus {
+	for _, status := range statuses {
+		if status.ID == id {
+			return status
+		}
+	}
+	return repository.WorkflowStatus{}
+}
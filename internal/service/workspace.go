@@ -0,0 +1,304 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"todo-api/internal/models"
+	"todo-api/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type WorkspaceService struct {
+	repo       repository.WorkspaceRepository
+	userRepo   repository.UserRepository
+	transactor repository.Transactor
+}
+
+func NewWorkspaceService(repo *repository.Repository) *WorkspaceService {
+	return &WorkspaceService{
+		repo:       repo.Workspace,
+		userRepo:   repo.User,
+		transactor: repo.Transactor,
+	}
+}
+
+func (s *WorkspaceService) CreateWorkspace(req models.CreateWorkspaceRequest, userID string) (*models.Workspace, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("Workspace name is required")
+	}
+
+	return createWorkspace(s.repo, name, userID)
+}
+
+// GetWorkspaces lists the workspaces userID belongs to, marking the active one.
+func (s *WorkspaceService) GetWorkspaces(userID, workspaceID string) ([]models.UserWorkspace, error) {
+	repoWorkspaces, err := s.repo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces := make([]models.UserWorkspace, len(repoWorkspaces))
+	for i, repoWorkspace := range repoWorkspaces {
+		member, err := s.repo.GetMember(repoWorkspace.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		workspaces[i] = models.UserWorkspace{
+			Workspace: models.ConvertFromRepositoryWorkspace(repoWorkspace),
+			Role:      member.Role,
+			Active:    repoWorkspace.ID == workspaceID,
+		}
+	}
+
+	return workspaces, nil
+}
+
+func (s *WorkspaceService) GetMembers(id, userID string) ([]models.WorkspaceMember, error) {
+	if _, err := s.getMemberWorkspace(id, userID); err != nil {
+		return nil, err
+	}
+
+	repoMembers, err := s.repo.GetMembers(id)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]models.WorkspaceMember, 0, len(repoMembers))
+	for _, repoMember := range repoMembers {
+		user, err := s.userRepo.GetByID(repoMember.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		if user == nil {
+			continue
+		}
+
+		members = append(members, models.WorkspaceMember{
+			UserID:    user.ID,
+			Name:      user.Name,
+			Email:     user.Email,
+			Role:      repoMember.Role,
+			CreatedAt: repoMember.CreatedAt,
+		})
+	}
+
+	return members, nil
+}
+
+// InviteMember lets the workspace owner invite a registered user. The user
+// joins the workspace only once they accept the invitation.
+func (s *WorkspaceService) InviteMember(id string, req models.InviteWorkspaceMemberRequest, userID string) (*models.WorkspaceInvitation, error) {
+	workspace, err := s.getMemberWorkspace(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if workspace.OwnerID != userID {
+		return nil, errors.New("Access denied")
+	}
+
+	user, err := s.userRepo.GetByEmail(req.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, errors.New("User not found")
+	}
+
+	existing, err := s.repo.GetMember(id, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil {
+		return nil, errors.New("User is already a member of the workspace")
+	}
+
+	pending, err := s.repo.GetInvitationsByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, invitation := range pending {
+		if invitation.WorkspaceID == id {
+			return nil, errors.New("User is already invited to the workspace")
+		}
+	}
+
+	invitation := repository.WorkspaceInvitation{
+		ID:          uuid.New().String(),
+		WorkspaceID: id,
+		UserID:      user.ID,
+		InvitedBy:   userID,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	if err := s.repo.SaveInvitation(invitation); err != nil {
+		return nil, err
+	}
+
+	return &models.WorkspaceInvitation{
+		ID:            invitation.ID,
+		WorkspaceID:   workspace.ID,
+		WorkspaceName: workspace.Name,
+		UserID:        user.ID,
+		Email:         user.Email,
+		InvitedBy:     invitation.InvitedBy,
+		CreatedAt:     invitation.CreatedAt,
+	}, nil
+}
+
+// GetInvitations lists the invitations userID has yet to answer.
+func (s *WorkspaceService) GetInvitations(userID string) ([]models.WorkspaceInvitation, error) {
+	repoInvitations, err := s.repo.GetInvitationsByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, errors.New("User not found")
+	}
+
+	invitations := make([]models.WorkspaceInvitation, 0, len(repoInvitations))
+	for _, repoInvitation := range repoInvitations {
+		workspace, err := s.repo.GetByID(repoInvitation.WorkspaceID)
+		if err != nil {
+			return nil, err
+		}
+
+		if workspace == nil {
+			continue
+		}
+
+		invitations = append(invitations, models.WorkspaceInvitation{
+			ID:            repoInvitation.ID,
+			WorkspaceID:   workspace.ID,
+			WorkspaceName: workspace.Name,
+			UserID:        user.ID,
+			Email:         user.Email,
+			InvitedBy:     repoInvitation.InvitedBy,
+			CreatedAt:     repoInvitation.CreatedAt,
+		})
+	}
+
+	return invitations, nil
+}
+
+// AcceptInvitation makes userID a member of the workspace they were invited to.
+func (s *WorkspaceService) AcceptInvitation(id, userID string) (*models.Workspace, error) {
+	invitation, err := s.getInvitation(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	workspace, err := s.repo.GetByID(invitation.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if workspace == nil {
+		return nil, errors.New("Invitation not found")
+	}
+
+	err = s.transactor.InTransaction(func(repo *repository.Repository) error {
+		err := repo.Workspace.SaveMember(repository.WorkspaceMember{
+			WorkspaceID: invitation.WorkspaceID,
+			UserID:      userID,
+			Role:        models.WorkspaceRoleMember,
+			CreatedAt:   time.Now().UTC(),
+		})
+		if err != nil {
+			return err
+		}
+
+		return repo.Workspace.DeleteInvitation(invitation.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := models.ConvertFromRepositoryWorkspace(*workspace)
+	return &result, nil
+}
+
+func (s *WorkspaceService) DeclineInvitation(id, userID string) error {
+	invitation, err := s.getInvitation(id, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.DeleteInvitation(invitation.ID)
+}
+
+// getInvitation finds an invitation addressed to userID.
+func (s *WorkspaceService) getInvitation(id, userID string) (*repository.WorkspaceInvitation, error) {
+	invitation, err := s.repo.GetInvitation(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if invitation == nil || invitation.UserID != userID {
+		return nil, errors.New("Invitation not found")
+	}
+
+	return invitation, nil
+}
+
+func (s *WorkspaceService) getMemberWorkspace(id, userID string) (*repository.Workspace, error) {
+	workspace, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if workspace == nil {
+		return nil, errors.New("Workspace not found")
+	}
+
+	member, err := s.repo.GetMember(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if member == nil {
+		return nil, errors.New("Workspace not found")
+	}
+
+	return workspace, nil
+}
+
+// createWorkspace creates a workspace with ownerID as its first member.
+func createWorkspace(repo repository.WorkspaceRepository, name, ownerID string) (*models.Workspace, error) {
+	workspace := models.Workspace{
+		ID:        uuid.New().String(),
+		Name:      name,
+		OwnerID:   ownerID,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err := repo.Create(workspace.ConvertToRepositoryWorkspace()); err != nil {
+		return nil, err
+	}
+
+	err := repo.SaveMember(repository.WorkspaceMember{
+		WorkspaceID: workspace.ID,
+		UserID:      ownerID,
+		Role:        models.WorkspaceRoleOwner,
+		CreatedAt:   workspace.CreatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &workspace, nil
+}